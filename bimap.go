@@ -0,0 +1,381 @@
+package utils
+
+import "iter"
+
+/*
+A map that keeps its values unique, so it can be looked up from both sides
+Implements the Map interface, and Inverse returns the same mappings as a BiMap from values to keys.
+Mapping a value that is already mapped to another key is a conflict, and every method treats it the same way:
+the Map methods Put, PutIfAbsent, Replace, Merge and the Compute methods leave the map unchanged
+and panic with an *OpError wrapping ErrConflict, TryPut returns that error instead,
+and ForcePut is the only method that resolves the conflict by removing the other key.
+Use ContainsValue or KeyOf to check for a conflict beforehand
+*/
+type BiMap[K comparable, V comparable] struct {
+	forward  map[K]V
+	backward map[V]K
+	inverse  *BiMap[V, K]
+}
+
+/*
+O(1)
+Instantiates a new empty BiMap
+*/
+func NewBiMap[K comparable, V comparable]() *BiMap[K, V] {
+	m := &BiMap[K, V]{
+		forward:  make(map[K]V),
+		backward: make(map[V]K)}
+	m.inverse = &BiMap[V, K]{
+		forward:  m.backward,
+		backward: m.forward,
+		inverse:  m}
+	return m
+}
+
+/*
+O(1)
+Assumes: BiMap m has been instantiated
+Returns a live view of the map from values to keys
+Both maps share their mappings, so a change through one is visible through the other
+*/
+func (m *BiMap[K, V]) Inverse() *BiMap[V, K] {
+	return m.inverse
+}
+
+/*
+O(1)
+Assumes: BiMap m has been instantiated
+Maps the key to the value, replacing the previous value of the key
+Returns error and leaves the map unchanged if the value is mapped to another key
+*/
+func (m *BiMap[K, V]) TryPut(key K, value V) error {
+	if m.conflicts(key, value) {
+		return m.conflictError("BiMap.TryPut")
+	}
+	m.set(key, value)
+	return nil
+}
+
+/*
+O(1)
+Assumes: BiMap m has been instantiated
+Maps the key to the value, removing the mapping of any other key to the value
+Returns the key that lost the value and true, or the nil value of the key type and false if there was none
+*/
+func (m *BiMap[K, V]) ForcePut(key K, value V) (K, bool) {
+	owner, exists := m.backward[value]
+	evicted := exists && owner != key
+	if evicted {
+		delete(m.forward, owner)
+	} else {
+		var nilKey K
+		owner = nilKey
+	}
+	m.set(key, value)
+	return owner, evicted
+}
+
+/*
+O(1)
+Assumes: BiMap m has been instantiated
+Ensures: the key is mapped to the value
+Panics with an error wrapping ErrConflict and leaves the map unchanged if the value is mapped to another key
+*/
+func (m *BiMap[K, V]) Put(key K, value V) {
+	m.rejectConflict("BiMap.Put", key, value)
+	m.set(key, value)
+}
+
+/*
+O(1)
+Assumes: BiMap m has been instantiated
+Returns the value that is associated with the supplied key
+If there is no mapping, the function will return the nil value of the value type
+*/
+func (m BiMap[K, V]) Get(key K) V {
+	return m.forward[key]
+}
+
+/*
+O(1)
+Assumes: BiMap m has been instantiated
+Returns the value that is associated with the supplied key and true,
+or the nil value of the value type and false if there is no mapping
+*/
+func (m BiMap[K, V]) GetOk(key K) (V, bool) {
+	value, exists := m.forward[key]
+	return value, exists
+}
+
+/*
+O(1)
+Assumes: BiMap m has been instantiated
+Returns the value that is associated with the supplied key, or defaultValue if there is no mapping
+*/
+func (m BiMap[K, V]) GetOrDefault(key K, defaultValue V) V {
+	if value, exists := m.forward[key]; exists {
+		return value
+	}
+	return defaultValue
+}
+
+/*
+O(1)
+Assumes: BiMap m has been instantiated
+Returns the key that is associated with the supplied value and true,
+or the nil value of the key type and false if there is no mapping
+*/
+func (m BiMap[K, V]) KeyOf(value V) (K, bool) {
+	key, exists := m.backward[value]
+	return key, exists
+}
+
+/*
+O(1)
+Assumes: BiMap m has been instantiated
+Returns true if there exist a mapping with the supplied value
+*/
+func (m BiMap[K, V]) ContainsValue(value V) bool {
+	_, exists := m.backward[value]
+	return exists
+}
+
+/*
+O(1)
+Assumes: BiMap m has been instantiated
+Maps the key to the value only if there is no mapping with the supplied key
+Returns the value the key is mapped to afterwards and true if it was already mapped
+Panics with an error wrapping ErrConflict and leaves the map unchanged if the key is added with a value that is mapped to another key
+*/
+func (m *BiMap[K, V]) PutIfAbsent(key K, value V) (V, bool) {
+	if old, exists := m.forward[key]; exists {
+		return old, true
+	}
+	m.rejectConflict("BiMap.PutIfAbsent", key, value)
+	m.set(key, value)
+	return value, false
+}
+
+/*
+O(1)
+Assumes: BiMap m has been instantiated
+Maps the key to the value only if there already is a mapping with the supplied key
+Returns the replaced value and true, or the nil value of the value type and false if there was no mapping
+Panics with an error wrapping ErrConflict and leaves the map unchanged if the value is mapped to another key
+*/
+func (m *BiMap[K, V]) Replace(key K, value V) (V, bool) {
+	old, exists := m.forward[key]
+	if !exists {
+		var nilVal V
+		return nilVal, false
+	}
+	m.rejectConflict("BiMap.Replace", key, value)
+	m.set(key, value)
+	return old, true
+}
+
+/*
+O(1)
+Assumes: BiMap m has been instantiated
+Removes the mapping with the supplied key
+Returns the removed value and true, or the nil value of the value type and false if there was no mapping
+*/
+func (m *BiMap[K, V]) Remove(key K) (V, bool) {
+	value, exists := m.forward[key]
+	if exists {
+		delete(m.forward, key)
+		delete(m.backward, value)
+	}
+	return value, exists
+}
+
+/*
+O(n)
+Assumes: BiMap m has been instantiated
+Returns the keys as a slice
+*/
+func (m BiMap[K, V]) Keys() []K {
+	keys := make([]K, 0, len(m.forward))
+	for k := range m.forward {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+/*
+O(n)
+Assumes: BiMap m has been instantiated
+Returns the values as a slice
+*/
+func (m BiMap[K, V]) Values() []V {
+	vals := make([]V, 0, len(m.backward))
+	for v := range m.backward {
+		vals = append(vals, v)
+	}
+	return vals
+}
+
+/*
+O(1)
+Assumes: BiMap m has been instantiated
+Returns true if there exist a mapping with the supplied key
+*/
+func (m BiMap[K, V]) ContainsKey(key K) bool {
+	_, exists := m.forward[key]
+	return exists
+}
+
+/*
+O(1)
+Assumes: BiMap m has been instantiated
+If there exists a mapping with the supplied key, the new value will be mergeOp(newVal, oldVal)
+If not, it works as a regular Put
+Panics with an error wrapping ErrConflict and leaves the map unchanged if the resulting value is mapped to another key
+*/
+func (m *BiMap[K, V]) Merge(key K, newVal V, mergeOp func(V, V) V) {
+	if old, exists := m.forward[key]; exists {
+		newVal = mergeOp(newVal, old)
+	}
+	m.rejectConflict("BiMap.Merge", key, newVal)
+	m.set(key, newVal)
+}
+
+/*
+O(1)
+Assumes: BiMap m has been instantiated
+Updates the mapping with the supplied key
+remap receives the current value and whether it exists, and returns the new value
+and whether the mapping should exist afterwards, so returning false removes it
+Returns the value after the update and whether the mapping exists
+Panics with an error wrapping ErrConflict and leaves the map unchanged if the new value is mapped to another key
+*/
+func (m *BiMap[K, V]) Compute(key K, remap func(V, bool) (V, bool)) (V, bool) {
+	old, exists := m.forward[key]
+	value, keep := remap(old, exists)
+	if !keep {
+		m.Remove(key)
+		var nilVal V
+		return nilVal, false
+	}
+	m.rejectConflict("BiMap.Compute", key, value)
+	m.set(key, value)
+	return value, true
+}
+
+/*
+O(1)
+Assumes: BiMap m has been instantiated
+Adds a mapping with the value returned by create if there is no mapping with the supplied key
+Nothing is added if create returns false, and a value that is mapped to another key panics like Compute
+Returns the value after the update and whether the mapping exists
+*/
+func (m *BiMap[K, V]) ComputeIfAbsent(key K, create func() (V, bool)) (V, bool) {
+	return m.Compute(key, absentRemap(create))
+}
+
+/*
+O(1)
+Assumes: BiMap m has been instantiated
+Replaces the value of an existing mapping with the value returned by remap
+The mapping is removed if remap returns false, and nothing happens if there is no mapping
+A new value that is mapped to another key panics like Compute
+Returns the value after the update and whether the mapping exists
+*/
+func (m *BiMap[K, V]) ComputeIfPresent(key K, remap func(V) (V, bool)) (V, bool) {
+	return m.Compute(key, presentRemap(remap))
+}
+
+/*
+O(1)
+Assumes: BiMap m has been instantiated
+Returns the number of mappings
+*/
+func (m BiMap[K, V]) Size() int {
+	return len(m.forward)
+}
+
+/*
+O(n)
+Assumes: BiMap m has been instantiated
+Removes every mapping, also from the inverse
+*/
+func (m *BiMap[K, V]) Clear() {
+	clear(m.forward)
+	clear(m.backward)
+}
+
+/*
+O(1)
+Assumes: BiMap m has been instantiated
+Returns an iterator over the mappings in no particular order
+The map must not be modified during the iteration
+*/
+func (m BiMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range m.forward {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+/*
+O(1)
+Assumes: BiMap m has been instantiated
+Returns an iterator over the keys in no particular order
+The map must not be modified during the iteration
+*/
+func (m BiMap[K, V]) KeysSeq() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range m.forward {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+/*
+O(1)
+Assumes: BiMap m has been instantiated
+Returns an iterator over the values in no particular order
+The map must not be modified during the iteration
+*/
+func (m BiMap[K, V]) ValuesSeq() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for v := range m.backward {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// PRIVATE HELPER FUNCTIONS BELOW
+
+// true if the value is mapped to a key other than the supplied one
+func (m BiMap[K, V]) conflicts(key K, value V) bool {
+	owner, exists := m.backward[value]
+	return exists && owner != key
+}
+
+func (m BiMap[K, V]) conflictError(op string) error {
+	return &OpError{Op: op, Err: ErrConflict}
+}
+
+// panics if the value is mapped to a key other than the supplied one
+func (m BiMap[K, V]) rejectConflict(op string, key K, value V) {
+	if m.conflicts(key, value) {
+		panic(m.conflictError(op))
+	}
+}
+
+// assumes that the value does not conflict
+func (m *BiMap[K, V]) set(key K, value V) {
+	if old, exists := m.forward[key]; exists {
+		delete(m.backward, old)
+	}
+	m.forward[key] = value
+	m.backward[value] = key
+}
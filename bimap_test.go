@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestBiMap_InverseIsLive(t *testing.T) {
+	m := NewBiMap[int, string]()
+	inverse := m.Inverse()
+	m.Put(1, "One")
+	inverse.Put("Two", 2)
+
+	if m.Get(2) != "Two" || inverse.Get("One") != 1 {
+		t.Errorf("Expected both sides to see every mapping")
+	}
+	if key, exists := m.KeyOf("Two"); !exists || key != 2 {
+		t.Errorf("Expected (2, true), but got (%d, %v)", key, exists)
+	}
+	if inverse.Inverse() != m {
+		t.Errorf("Expected the inverse of the inverse to be the map itself")
+	}
+
+	// Overwriting a key releases its old value
+	m.Put(1, "Uno")
+	if inverse.ContainsKey("One") || inverse.Get("Uno") != 1 {
+		t.Errorf("Expected 'One' to be released when key 1 got a new value")
+	}
+
+	inverse.Remove("Two")
+	if m.ContainsKey(2) || m.Size() != 1 || inverse.Size() != 1 {
+		t.Errorf("Expected removing through the inverse to remove from the map")
+	}
+	m.Clear()
+	if inverse.Size() != 0 {
+		t.Errorf("Expected Clear to empty the inverse as well")
+	}
+}
+
+func TestBiMap_Conflicts(t *testing.T) {
+	m := NewBiMap[int, string]()
+	m.Put(1, "One")
+
+	if err := m.TryPut(2, "One"); !errors.Is(err, ErrConflict) {
+		t.Errorf("TryPut should return an error wrapping ErrConflict when the value is mapped to another key, but got %v", err)
+	}
+	if err := m.TryPut(1, "One"); err != nil {
+		t.Errorf("TryPut should accept remapping a key to its own value, but got %v", err)
+	}
+	if _, replaced := m.Replace(1, "One"); !replaced {
+		t.Errorf("Expected Replace with the same value to succeed")
+	}
+	m.Put(2, "Two")
+
+	evicted, ok := m.ForcePut(3, "One")
+	if !ok || evicted != 1 {
+		t.Errorf("Expected ForcePut to evict key 1, but got (%d, %v)", evicted, ok)
+	}
+	if _, ok := m.ForcePut(3, "Three"); ok {
+		t.Errorf("Expected ForcePut without a conflict to evict nothing")
+	}
+	keys := m.Keys()
+	sort.Ints(keys)
+	if !reflect.DeepEqual(keys, []int{2, 3}) || m.ContainsValue("One") {
+		t.Errorf("Expected keys [2 3] without 'One', but got %v", keys)
+	}
+	if m.Size() != len(m.Values()) {
+		t.Errorf("Expected as many values as keys")
+	}
+}
+
+func TestBiMap_MapMethodsRejectConflicts(t *testing.T) {
+	calls := map[string]func(m *BiMap[int, string]){
+		"BiMap.Put":         func(m *BiMap[int, string]) { m.Put(3, "One") },
+		"BiMap.PutIfAbsent": func(m *BiMap[int, string]) { m.PutIfAbsent(3, "One") },
+		"BiMap.Merge": func(m *BiMap[int, string]) {
+			m.Merge(2, "One", func(newVal, _ string) string { return newVal })
+		},
+		"BiMap.Replace": func(m *BiMap[int, string]) { m.Replace(2, "One") },
+		"BiMap.Compute": func(m *BiMap[int, string]) {
+			m.Compute(2, func(string, bool) (string, bool) { return "One", true })
+		},
+		"BiMap.Compute (absent)": func(m *BiMap[int, string]) {
+			m.ComputeIfAbsent(3, func() (string, bool) { return "One", true })
+		},
+		"BiMap.Compute (present)": func(m *BiMap[int, string]) {
+			m.ComputeIfPresent(2, func(string) (string, bool) { return "One", true })
+		},
+	}
+	for name, call := range calls {
+		m := NewBiMap[int, string]()
+		m.Put(1, "One")
+		m.Put(2, "Two")
+
+		err := catchPanic(func() { call(m) })
+		op, _, _ := strings.Cut(name, " (")
+		var opErr *OpError
+		if !errors.Is(err, ErrConflict) || !errors.As(err, &opErr) || opErr.Op != op {
+			t.Errorf("%s: expected a panic with an OpError wrapping ErrConflict, but got %v", name, err)
+		}
+
+		// Both directions are left as they were
+		inverse := m.Inverse()
+		if m.Size() != 2 || inverse.Size() != 2 {
+			t.Errorf("%s: expected 2 mappings, but got %d and %d in the inverse", name, m.Size(), inverse.Size())
+		}
+		if m.Get(1) != "One" || m.Get(2) != "Two" || m.ContainsKey(3) {
+			t.Errorf("%s: expected the forward map to be unchanged", name)
+		}
+		if inverse.Get("One") != 1 || inverse.Get("Two") != 2 {
+			t.Errorf("%s: expected the inverse map to be unchanged", name)
+		}
+	}
+}
+
+// runs f and returns the error it panicked with, or nil if it did not panic
+func catchPanic(f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err, _ = r.(error)
+		}
+	}()
+	f()
+	return nil
+}
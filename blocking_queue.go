@@ -0,0 +1,299 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+/*
+A FIFO queue that is safe for concurrent use by producers and consumers
+Put and Take block until they can proceed or their context is done.
+With a capacity the queue applies back-pressure: Put blocks while the queue is full.
+After Close every blocked call wakes up, Put fails and Take keeps draining the remaining items
+before it fails as well.
+The Queue interface methods never block on an empty queue, but Enqueue blocks on a full one
+*/
+type BlockingQueue[T interface{}] struct {
+	mu       sync.Mutex
+	contents *ring[T]
+	capacity int
+	closed   bool
+	// closed and replaced to wake every waiter when an item is added or the queue is closed
+	notEmpty chan struct{}
+	// closed and replaced to wake every waiter when an item is removed or the queue is closed
+	notFull chan struct{}
+}
+
+/*
+O(1)
+Instantiates a new unbounded BlockingQueue
+*/
+func NewBlockingQueue[T interface{}]() *BlockingQueue[T] {
+	return NewBoundedBlockingQueue[T](0)
+}
+
+/*
+O(1)
+Instantiates a new BlockingQueue that holds at most capacity items
+A capacity <= 0 means that the queue is unbounded
+*/
+func NewBoundedBlockingQueue[T interface{}](capacity int) *BlockingQueue[T] {
+	return &BlockingQueue[T]{
+		contents: newRing[T](defaultRingCapacity),
+		capacity: capacity,
+		notEmpty: make(chan struct{}),
+		notFull:  make(chan struct{})}
+}
+
+/*
+O(1) amortized
+Assumes: the queue has been instantiated
+Places the item at the back of the queue, waiting for room if the queue is full
+Returns error if the queue is closed, or the context error if ctx is done first
+*/
+func (q *BlockingQueue[T]) Put(ctx context.Context, item T) error {
+	q.mu.Lock()
+	for {
+		if q.closed {
+			q.mu.Unlock()
+			return &OpError{Op: "BlockingQueue.Put", Err: ErrClosed}
+		}
+		if q.capacity <= 0 || q.contents.size < q.capacity {
+			q.contents.pushBack(item)
+			q.signalNotEmpty()
+			q.mu.Unlock()
+			return nil
+		}
+		wait := q.notFull
+		q.mu.Unlock()
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		q.mu.Lock()
+	}
+}
+
+/*
+O(1) amortized
+Assumes: the queue has been instantiated
+Removes the item at the front of the queue and returns it, waiting for an item if the queue is empty
+Returns error if the queue is closed and drained, or the context error if ctx is done first
+*/
+func (q *BlockingQueue[T]) Take(ctx context.Context) (T, error) {
+	var nilVal T
+	q.mu.Lock()
+	for {
+		if q.contents.size > 0 {
+			item := q.contents.popFront()
+			q.signalNotFull()
+			q.mu.Unlock()
+			return item, nil
+		}
+		if q.closed {
+			q.mu.Unlock()
+			return nilVal, &OpError{Op: "BlockingQueue.Take", Err: ErrClosed}
+		}
+		wait := q.notEmpty
+		q.mu.Unlock()
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return nilVal, ctx.Err()
+		}
+		q.mu.Lock()
+	}
+}
+
+/*
+Assumes: the queue has been instantiated
+Places the item at the back of the queue, waiting at most timeout for room
+Returns error if the queue is still full after the timeout or if it is closed
+*/
+func (q *BlockingQueue[T]) Offer(item T, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	err := q.Put(ctx, item)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return &OpError{Op: "BlockingQueue.Offer", Err: ErrFull}
+	} else if errors.Is(err, ErrClosed) {
+		return &OpError{Op: "BlockingQueue.Offer", Err: ErrClosed}
+	}
+	return err
+}
+
+/*
+Assumes: the queue has been instantiated
+Removes the item at the front of the queue and returns it, waiting at most timeout for an item
+Returns error if the queue is still empty after the timeout or if it is closed and drained
+*/
+func (q *BlockingQueue[T]) Poll(timeout time.Duration) (T, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	item, err := q.Take(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return item, &OpError{Op: "BlockingQueue.Poll", Err: ErrEmpty}
+	} else if errors.Is(err, ErrClosed) {
+		return item, &OpError{Op: "BlockingQueue.Poll", Err: ErrClosed}
+	}
+	return item, err
+}
+
+/*
+O(1)
+Assumes: the queue has been instantiated
+Closes the queue and wakes every blocked Put and Take
+Items that are already in the queue can still be taken
+No-op if the queue is already closed
+*/
+func (q *BlockingQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+	q.closed = true
+	q.signalNotEmpty()
+	q.signalNotFull()
+}
+
+/*
+O(1)
+Assumes: the queue has been instantiated
+Returns true if Close has been called
+*/
+func (q *BlockingQueue[T]) IsClosed() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.closed
+}
+
+/*
+O(1) amortized
+Assumes: the queue has been instantiated
+Places the item at the back of the queue, waiting for room if the queue is full
+The item is dropped if the queue is closed
+*/
+func (q *BlockingQueue[T]) Enqueue(item T) {
+	q.Put(context.Background(), item)
+}
+
+/*
+O(n)
+Assumes: the queue has been instantiated
+Enqueues the items one by one, waiting for room whenever the queue is full
+*/
+func (q *BlockingQueue[T]) EnqueueAll(items []T) {
+	for _, item := range items {
+		q.Put(context.Background(), item)
+	}
+}
+
+/*
+O(1) amortized
+Assumes: the queue has been instantiated
+Removes the item at the front of the queue and returns it without waiting
+Returns error if the queue is empty
+*/
+func (q *BlockingQueue[T]) Dequeue() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.contents.size == 0 {
+		var nilVal T
+		return nilVal, &OpError{Op: "BlockingQueue.Dequeue", Err: ErrEmpty}
+	}
+	item := q.contents.popFront()
+	q.signalNotFull()
+	return item, nil
+}
+
+/*
+O(n)
+Assumes: the queue has been instantiated
+Removes all items in the queue and returns them in the order they were placed in the queue
+*/
+func (q *BlockingQueue[T]) DequeueAll() []T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	out := q.contents.toSlice()
+	q.contents.clear()
+	q.signalNotFull()
+	return out
+}
+
+/*
+O(1)
+Assumes: the queue has been instantiated
+Returns the item at the front of the queue without removing it
+Returns error if the queue is empty
+*/
+func (q *BlockingQueue[T]) Peek() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.contents.size == 0 {
+		var nilVal T
+		return nilVal, &OpError{Op: "BlockingQueue.Peek", Err: ErrEmpty}
+	}
+	return q.contents.at(0), nil
+}
+
+/*
+O(1)
+Assumes: the queue has been instantiated
+Returns true if there are no items in the queue
+*/
+func (q *BlockingQueue[T]) IsEmpty() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.contents.size == 0
+}
+
+/*
+O(1)
+Assumes: the queue has been instantiated
+Returns the number of items in the queue
+*/
+func (q *BlockingQueue[T]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.contents.size
+}
+
+/*
+O(1)
+Removes every item and wakes producers that are waiting for room
+*/
+func (q *BlockingQueue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.contents.clear()
+	q.signalNotFull()
+}
+
+/*
+O(n)
+Assumes: the queue has been instantiated
+Returns the queue as a slice
+*/
+func (q *BlockingQueue[T]) ToSlice() []T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.contents.toSlice()
+}
+
+// PRIVATE HELPER FUNCTIONS BELOW
+
+// assumes the lock is held
+func (q *BlockingQueue[T]) signalNotEmpty() {
+	close(q.notEmpty)
+	q.notEmpty = make(chan struct{})
+}
+
+// assumes the lock is held
+func (q *BlockingQueue[T]) signalNotFull() {
+	close(q.notFull)
+	q.notFull = make(chan struct{})
+}
@@ -0,0 +1,210 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlockingQueue_AsQueue(t *testing.T) {
+	var q Queue[int] = NewBlockingQueue[int]()
+	q.EnqueueAll([]int{1, 2, 3})
+	q.Enqueue(4)
+
+	item, err := q.Peek()
+	if err != nil || item != 1 {
+		t.Errorf("Expected Peek to return 1, but got %d (err: %v)", item, err)
+	}
+	item, _ = q.Dequeue()
+	if item != 1 {
+		t.Errorf("Expected Dequeue to return 1, but got %d", item)
+	}
+	if items := q.DequeueAll(); !reflect.DeepEqual(items, []int{2, 3, 4}) {
+		t.Errorf("Expected [2 3 4], but got %v", items)
+	}
+	if _, err := q.Dequeue(); err == nil {
+		t.Errorf("Dequeue should not block and return an error when the queue is empty")
+	}
+}
+
+func TestBlockingQueue_TakeWaitsForPut(t *testing.T) {
+	q := NewBlockingQueue[int]()
+	result := make(chan int)
+	go func() {
+		item, _ := q.Take(context.Background())
+		result <- item
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	q.Put(context.Background(), 42)
+	select {
+	case item := <-result:
+		if item != 42 {
+			t.Errorf("Expected 42, but got %d", item)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Take was not woken by Put")
+	}
+}
+
+func TestBlockingQueue_Cancellation(t *testing.T) {
+	q := NewBoundedBlockingQueue[int](1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := q.Take(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected Take to return context.Canceled, but got %v", err)
+	}
+	q.Put(context.Background(), 1)
+	if err := q.Put(ctx, 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected Put on a full queue to return context.Canceled, but got %v", err)
+	}
+	if q.Size() != 1 {
+		t.Errorf("Expected size 1, but got %d", q.Size())
+	}
+}
+
+func TestBlockingQueue_OfferAndPollTimeout(t *testing.T) {
+	q := NewBoundedBlockingQueue[int](2)
+	if err := q.Offer(1, time.Millisecond); err != nil {
+		t.Errorf("Expected Offer to succeed, but got %v", err)
+	}
+	if err := q.Offer(2, time.Millisecond); err != nil {
+		t.Errorf("Expected Offer to succeed, but got %v", err)
+	}
+	if err := q.Offer(3, 10*time.Millisecond); err == nil {
+		t.Errorf("Expected Offer on a full queue to time out")
+	}
+
+	q.Clear()
+	if _, err := q.Poll(10 * time.Millisecond); err == nil {
+		t.Errorf("Expected Poll on an empty queue to time out")
+	}
+}
+
+func TestBlockingQueue_BackPressure(t *testing.T) {
+	q := NewBoundedBlockingQueue[int](1)
+	q.Put(context.Background(), 1)
+
+	done := make(chan error)
+	go func() {
+		done <- q.Put(context.Background(), 2)
+	}()
+
+	select {
+	case <-done:
+		t.Fatalf("Put should block while the queue is full")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	item, _ := q.Take(context.Background())
+	if item != 1 {
+		t.Errorf("Expected 1, but got %d", item)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected the blocked Put to succeed, but got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Put was not woken by Take")
+	}
+}
+
+func TestBlockingQueue_CloseWakesWaiters(t *testing.T) {
+	empty := NewBlockingQueue[int]()
+	full := NewBoundedBlockingQueue[int](1)
+	full.Put(context.Background(), 1)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 6)
+	for i := 0; i < 3; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := empty.Take(context.Background())
+			errs <- err
+		}()
+		go func() {
+			defer wg.Done()
+			errs <- full.Put(context.Background(), 2)
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	empty.Close()
+	full.Close()
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err == nil {
+			t.Errorf("Expected every waiter to fail after Close")
+		}
+	}
+
+	// Remaining items can still be taken, then Take fails
+	item, err := full.Take(context.Background())
+	if err != nil || item != 1 {
+		t.Errorf("Expected to drain 1 after Close, but got %d (err: %v)", item, err)
+	}
+	if _, err := full.Take(context.Background()); err == nil {
+		t.Errorf("Expected Take on a closed and drained queue to fail")
+	}
+	if !full.IsClosed() {
+		t.Errorf("Expected IsClosed to report true")
+	}
+}
+
+func TestBlockingQueue_ProducersAndConsumers(t *testing.T) {
+	q := NewBoundedBlockingQueue[int](8)
+	producers := 4
+	perProducer := 1000
+
+	var producersDone sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		producersDone.Add(1)
+		go func(p int) {
+			defer producersDone.Done()
+			for i := 0; i < perProducer; i++ {
+				q.Put(context.Background(), p*perProducer+i)
+			}
+		}(p)
+	}
+
+	var mu sync.Mutex
+	received := make([]int, 0, producers*perProducer)
+	var consumersDone sync.WaitGroup
+	for c := 0; c < 4; c++ {
+		consumersDone.Add(1)
+		go func() {
+			defer consumersDone.Done()
+			for {
+				item, err := q.Take(context.Background())
+				if err != nil {
+					return
+				}
+				mu.Lock()
+				received = append(received, item)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	producersDone.Wait()
+	q.Close()
+	consumersDone.Wait()
+
+	if len(received) != producers*perProducer {
+		t.Fatalf("Expected %d items, but got %d", producers*perProducer, len(received))
+	}
+	sort.Ints(received)
+	for i, item := range received {
+		if item != i {
+			t.Fatalf("Expected every item exactly once, but found %d at position %d", item, i)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package utils
+
+import (
+	"iter"
+	"slices"
+)
+
+/*
+A priority queue that keeps only the capacity best items it has been offered
+The best item is the one the comparator orders first, like the top of a PriorityQueue.
+When the queue is full, a new item is accepted only if it is better than the worst retained item,
+which is then evicted. Implements the Queue interface with the best item in front
+*/
+type BoundedPriorityQueue[T interface{}] struct {
+	heap     *MinMaxHeap[T]
+	capacity int
+}
+
+/*
+O(1)
+Instantiates a new empty BoundedPriorityQueue retaining at most capacity items
+A capacity smaller than 1 is treated as 1
+*/
+func NewBoundedPriorityQueue[T interface{}](capacity int, comp func(T, T) int) *BoundedPriorityQueue[T] {
+	return &BoundedPriorityQueue[T]{
+		heap:     NewMinMaxHeap(comp),
+		capacity: max(capacity, 1)}
+}
+
+/*
+O(log k) where k is the capacity
+Assumes: BoundedPriorityQueue q has been instantiated
+Adds the item if the queue is not full or if the item is better than the worst retained item, which is then evicted
+Returns true if the item was accepted
+*/
+func (q *BoundedPriorityQueue[T]) Offer(item T) bool {
+	if q.heap.Size() < q.capacity {
+		q.heap.Enqueue(item)
+		return true
+	}
+	worst, _ := q.heap.PeekMax()
+	if q.heap.comparator(item, worst) >= 0 {
+		return false
+	}
+	q.heap.PopMax()
+	q.heap.Enqueue(item)
+	return true
+}
+
+/*
+O(log k) where k is the capacity
+Assumes: BoundedPriorityQueue q has been instantiated
+Queue operation: offers the item, discarding whether it was accepted
+*/
+func (q *BoundedPriorityQueue[T]) Enqueue(item T) {
+	q.Offer(item)
+}
+
+/*
+O(m log k) where m is the number of items and k is the capacity
+Assumes: BoundedPriorityQueue q has been instantiated
+Offers all items
+*/
+func (q *BoundedPriorityQueue[T]) EnqueueAll(items []T) {
+	for _, item := range items {
+		q.Offer(item)
+	}
+}
+
+/*
+O(log k) where k is the capacity
+Assumes: BoundedPriorityQueue q has been instantiated
+Removes and returns the best item
+Returns error if the queue is empty
+*/
+func (q *BoundedPriorityQueue[T]) Dequeue() (T, error) {
+	if q.heap.IsEmpty() {
+		var nilVal T
+		return nilVal, &OpError{Op: "BoundedPriorityQueue.Dequeue", Err: ErrEmpty}
+	}
+	return q.heap.removeAt(0), nil
+}
+
+/*
+O(k log k) where k is the capacity
+Assumes: BoundedPriorityQueue q has been instantiated
+Removes all items and returns them from the best to the worst
+*/
+func (q *BoundedPriorityQueue[T]) DequeueAll() []T {
+	return q.heap.DequeueAll()
+}
+
+/*
+O(1)
+Assumes: BoundedPriorityQueue q has been instantiated
+Returns the best item without removing it
+Returns error if the queue is empty
+*/
+func (q BoundedPriorityQueue[T]) Peek() (T, error) {
+	if q.heap.IsEmpty() {
+		var nilVal T
+		return nilVal, &OpError{Op: "BoundedPriorityQueue.Peek", Err: ErrEmpty}
+	}
+	return q.heap.contents[0], nil
+}
+
+/*
+O(1)
+Assumes: BoundedPriorityQueue q has been instantiated
+Returns the worst retained item, which is the item the next accepted offer will evict when the queue is full
+Returns error if the queue is empty
+*/
+func (q BoundedPriorityQueue[T]) PeekWorst() (T, error) {
+	if q.heap.IsEmpty() {
+		var nilVal T
+		return nilVal, &OpError{Op: "BoundedPriorityQueue.PeekWorst", Err: ErrEmpty}
+	}
+	return q.heap.contents[q.heap.maxIndex()], nil
+}
+
+/*
+O(k log k) where k is the capacity
+Assumes: BoundedPriorityQueue q has been instantiated
+Returns the retained items from the best to the worst without removing them
+*/
+func (q BoundedPriorityQueue[T]) Sorted() []T {
+	out := q.heap.ToSlice()
+	slices.SortFunc(out, q.heap.comparator)
+	return out
+}
+
+/*
+O(1)
+Assumes: BoundedPriorityQueue q has been instantiated
+Returns true if the queue is empty
+*/
+func (q BoundedPriorityQueue[T]) IsEmpty() bool {
+	return q.heap.IsEmpty()
+}
+
+/*
+O(1)
+Assumes: BoundedPriorityQueue q has been instantiated
+Returns true if the queue holds capacity items
+*/
+func (q BoundedPriorityQueue[T]) IsFull() bool {
+	return q.heap.Size() == q.capacity
+}
+
+/*
+O(1)
+Assumes: BoundedPriorityQueue q has been instantiated
+Returns the number of retained items
+*/
+func (q BoundedPriorityQueue[T]) Size() int {
+	return q.heap.Size()
+}
+
+/*
+O(1)
+Assumes: BoundedPriorityQueue q has been instantiated
+Returns the maximum number of retained items
+*/
+func (q BoundedPriorityQueue[T]) Capacity() int {
+	return q.capacity
+}
+
+/*
+O(1)
+Wipes the contents of the queue
+*/
+func (q *BoundedPriorityQueue[T]) Clear() {
+	q.heap.Clear()
+}
+
+/*
+O(k)
+Assumes: BoundedPriorityQueue q has been instantiated
+Returns the inner representation of the queue as a slice
+*/
+func (q BoundedPriorityQueue[T]) ToSlice() []T {
+	return q.heap.ToSlice()
+}
+
+/*
+O(k log k) where k is the capacity
+Assumes: BoundedPriorityQueue q has been instantiated
+Returns an iterator over the ranks and retained items from the best to the worst
+The queue itself is left untouched
+*/
+func (q BoundedPriorityQueue[T]) All() iter.Seq2[int, T] {
+	return q.heap.All()
+}
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestBoundedPriorityQueue_Offer(t *testing.T) {
+	q := NewBoundedPriorityQueue(3, cmp)
+	for _, item := range []int{5, 8, 2} {
+		if !q.Offer(item) {
+			t.Errorf("Expected %d to be accepted while the queue is not full", item)
+		}
+	}
+	if !q.IsFull() {
+		t.Errorf("Expected the queue to be full")
+	}
+	if q.Offer(9) || q.Offer(8) {
+		t.Errorf("Expected items that are not better than the worst to be rejected")
+	}
+	if !q.Offer(1) {
+		t.Errorf("Expected a better item to be accepted")
+	}
+
+	worst, _ := q.PeekWorst()
+	best, _ := q.Peek()
+	if worst != 5 || best != 1 {
+		t.Errorf("Expected best 1 and worst 5, but got %d and %d", best, worst)
+	}
+	expected := []int{1, 2, 5}
+	if sorted := q.Sorted(); !reflect.DeepEqual(sorted, expected) {
+		t.Errorf("Expected %v, but got %v", expected, sorted)
+	}
+	if q.Size() != 3 || q.Capacity() != 3 {
+		t.Errorf("Expected size and capacity 3, but got %d and %d", q.Size(), q.Capacity())
+	}
+}
+
+func TestBoundedPriorityQueue_TopK(t *testing.T) {
+	items := rand.Perm(10000)
+	q := NewBoundedPriorityQueue(10, func(a, b int) int { return b - a })
+	q.EnqueueAll(items)
+
+	expected := []int{9999, 9998, 9997, 9996, 9995, 9994, 9993, 9992, 9991, 9990}
+	if sorted := q.Sorted(); !reflect.DeepEqual(sorted, expected) {
+		t.Errorf("Expected %v, but got %v", expected, sorted)
+	}
+}
+
+func TestBoundedPriorityQueue_AsQueue(t *testing.T) {
+	var q Queue[int] = NewBoundedPriorityQueue(0, cmp)
+	q.EnqueueAll([]int{3, 1, 2})
+	if q.Size() != 1 {
+		t.Errorf("Expected a capacity below 1 to be treated as 1, but got size %d", q.Size())
+	}
+	item, err := q.Dequeue()
+	if err != nil || item != 1 {
+		t.Errorf("Expected 1, but got %d (err: %v)", item, err)
+	}
+	if _, err := q.Dequeue(); err == nil {
+		t.Errorf("Dequeue should return an error when the queue is empty")
+	}
+	if _, err := q.Peek(); err == nil {
+		t.Errorf("Peek should return an error when the queue is empty")
+	}
+}
+
+func TestBoundedPriorityQueue_All(t *testing.T) {
+	q := NewBoundedPriorityQueue(4, cmp)
+	q.EnqueueAll([]int{7, 3, 9, 1, 5})
+	items := make([]int, 0)
+	for _, item := range q.All() {
+		items = append(items, item)
+	}
+	if !slices.Equal(items, q.Sorted()) || q.Size() != 4 {
+		t.Errorf("Expected All to match Sorted without changing the queue, but got %v", items)
+	}
+}
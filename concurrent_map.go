@@ -0,0 +1,381 @@
+package utils
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"math"
+	"reflect"
+	"sync"
+)
+
+const defaultShardCount int = 32
+
+/*
+An implementation of the Map interface that is safe for concurrent use
+The keys are spread over independently locked shards by a hash function,
+so goroutines working on different shards never wait for each other.
+Every single-key operation is atomic. Keys, Values and Size visit the shards one at a time,
+so they are not a snapshot of the whole map when it is modified concurrently
+*/
+type ConcurrentMap[K comparable, V interface{}] struct {
+	shards []*mapShard[K, V]
+	hash   func(K) uint64
+}
+
+type mapShard[K comparable, V interface{}] struct {
+	mu    sync.RWMutex
+	items map[K]V
+}
+
+/*
+O(1)
+Instantiates a new empty ConcurrentMap with the default number of shards and hash function
+*/
+func NewConcurrentMap[K comparable, V interface{}]() *ConcurrentMap[K, V] {
+	return NewConcurrentMapWithHasher[K, V](defaultShardCount, newKeyHasher[K]())
+}
+
+/*
+O(shards)
+Instantiates a new empty ConcurrentMap with the supplied number of shards and hash function
+Equal keys must have equal hashes. A shard count smaller than 1 is treated as 1
+*/
+func NewConcurrentMapWithHasher[K comparable, V interface{}](shards int, hash func(K) uint64) *ConcurrentMap[K, V] {
+	m := &ConcurrentMap[K, V]{
+		shards: make([]*mapShard[K, V], max(shards, 1)),
+		hash:   hash}
+	for i := range m.shards {
+		m.shards[i] = &mapShard[K, V]{items: make(map[K]V)}
+	}
+	return m
+}
+
+/*
+O(1)
+Assumes: ConcurrentMap m has been instantiated
+Ensures: the key is mapped to the value
+*/
+func (m *ConcurrentMap[K, V]) Put(key K, value V) {
+	shard := m.shardFor(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	shard.items[key] = value
+}
+
+/*
+O(1)
+Assumes: ConcurrentMap m has been instantiated
+Returns the value that is associated with the supplied key
+If there is no mapping, the function will return the nil value of the value type
+*/
+func (m *ConcurrentMap[K, V]) Get(key K) V {
+	shard := m.shardFor(key)
+	shard.mu.RLock()
+	defer shard.mu.RUnlock()
+	return shard.items[key]
+}
+
+/*
+O(1)
+Assumes: ConcurrentMap m has been instantiated
+Returns the value that is associated with the supplied key and true,
+or the nil value of the value type and false if there is no mapping
+*/
+func (m *ConcurrentMap[K, V]) GetOk(key K) (V, bool) {
+	shard := m.shardFor(key)
+	shard.mu.RLock()
+	defer shard.mu.RUnlock()
+	value, exists := shard.items[key]
+	return value, exists
+}
+
+/*
+O(1)
+Assumes: ConcurrentMap m has been instantiated
+Returns the value that is associated with the supplied key, or defaultValue if there is no mapping
+*/
+func (m *ConcurrentMap[K, V]) GetOrDefault(key K, defaultValue V) V {
+	if value, exists := m.GetOk(key); exists {
+		return value
+	}
+	return defaultValue
+}
+
+/*
+O(1)
+Assumes: ConcurrentMap m has been instantiated
+Atomically maps the key to the value only if there is no mapping with the supplied key
+Returns the value the key is mapped to afterwards and true if it was already mapped
+*/
+func (m *ConcurrentMap[K, V]) PutIfAbsent(key K, value V) (V, bool) {
+	shard := m.shardFor(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	if old, exists := shard.items[key]; exists {
+		return old, true
+	}
+	shard.items[key] = value
+	return value, false
+}
+
+/*
+O(1)
+Assumes: ConcurrentMap m has been instantiated
+Atomically maps the key to the value only if there already is a mapping with the supplied key
+Returns the replaced value and true, or the nil value of the value type and false if nothing was replaced
+*/
+func (m *ConcurrentMap[K, V]) Replace(key K, value V) (V, bool) {
+	shard := m.shardFor(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	old, exists := shard.items[key]
+	if exists {
+		shard.items[key] = value
+	}
+	return old, exists
+}
+
+/*
+O(1)
+Assumes: ConcurrentMap m has been instantiated
+Atomically removes the mapping with the supplied key
+Returns the removed value and true, or the nil value of the value type and false if there was no mapping
+*/
+func (m *ConcurrentMap[K, V]) Remove(key K) (V, bool) {
+	shard := m.shardFor(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	value, exists := shard.items[key]
+	delete(shard.items, key)
+	return value, exists
+}
+
+/*
+O(n)
+Assumes: ConcurrentMap m has been instantiated
+Returns the keys as a slice
+*/
+func (m *ConcurrentMap[K, V]) Keys() []K {
+	keys := make([]K, 0)
+	for _, shard := range m.shards {
+		shard.mu.RLock()
+		for k := range shard.items {
+			keys = append(keys, k)
+		}
+		shard.mu.RUnlock()
+	}
+	return keys
+}
+
+/*
+O(n)
+Assumes: ConcurrentMap m has been instantiated
+Returns the values as a slice
+*/
+func (m *ConcurrentMap[K, V]) Values() []V {
+	vals := make([]V, 0)
+	for _, shard := range m.shards {
+		shard.mu.RLock()
+		for _, v := range shard.items {
+			vals = append(vals, v)
+		}
+		shard.mu.RUnlock()
+	}
+	return vals
+}
+
+/*
+O(1)
+Assumes: ConcurrentMap m has been instantiated
+Returns true if there exist a mapping with the supplied key
+*/
+func (m *ConcurrentMap[K, V]) ContainsKey(key K) bool {
+	shard := m.shardFor(key)
+	shard.mu.RLock()
+	defer shard.mu.RUnlock()
+	_, exists := shard.items[key]
+	return exists
+}
+
+/*
+O(1)
+Assumes: ConcurrentMap m has been instantiated
+Atomically: if there exists a mapping with the supplied key, the new value will be mergeOp(newVal, oldVal)
+If not, it works as a regular Put
+mergeOp runs while the shard is locked and must not use the map
+*/
+func (m *ConcurrentMap[K, V]) Merge(key K, newVal V, mergeOp func(V, V) V) {
+	shard := m.shardFor(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	if old, exists := shard.items[key]; exists {
+		newVal = mergeOp(newVal, old)
+	}
+	shard.items[key] = newVal
+}
+
+/*
+O(shards)
+Assumes: ConcurrentMap m has been instantiated
+Returns the number of mappings
+*/
+func (m *ConcurrentMap[K, V]) Size() int {
+	size := 0
+	for _, shard := range m.shards {
+		shard.mu.RLock()
+		size += len(shard.items)
+		shard.mu.RUnlock()
+	}
+	return size
+}
+
+/*
+O(1)
+Assumes: ConcurrentMap m has been instantiated
+Atomically updates the mapping with the supplied key
+remap receives the current value and whether it exists, and returns the new value
+and whether the mapping should exist afterwards, so returning false removes it
+Returns the value after the update and whether the mapping exists
+remap runs while the shard is locked and must not use the map
+*/
+func (m *ConcurrentMap[K, V]) Compute(key K, remap func(V, bool) (V, bool)) (V, bool) {
+	shard := m.shardFor(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	old, exists := shard.items[key]
+	value, keep := remap(old, exists)
+	if !keep {
+		delete(shard.items, key)
+		var nilVal V
+		return nilVal, false
+	}
+	shard.items[key] = value
+	return value, true
+}
+
+/*
+O(1)
+Assumes: ConcurrentMap m has been instantiated
+Atomically adds a mapping with the value returned by create if there is no mapping with the supplied key
+Nothing is added if create returns false
+Returns the value after the update and whether the mapping exists
+create runs while the shard is locked and must not use the map
+*/
+func (m *ConcurrentMap[K, V]) ComputeIfAbsent(key K, create func() (V, bool)) (V, bool) {
+	return m.Compute(key, absentRemap(create))
+}
+
+/*
+O(1)
+Assumes: ConcurrentMap m has been instantiated
+Atomically replaces the value of an existing mapping with the value returned by remap
+The mapping is removed if remap returns false, and nothing happens if there is no mapping
+Returns the value after the update and whether the mapping exists
+remap runs while the shard is locked and must not use the map
+*/
+func (m *ConcurrentMap[K, V]) ComputeIfPresent(key K, remap func(V) (V, bool)) (V, bool) {
+	return m.Compute(key, presentRemap(remap))
+}
+
+/*
+O(shards)
+Removes every mapping
+*/
+func (m *ConcurrentMap[K, V]) Clear() {
+	for _, shard := range m.shards {
+		shard.mu.Lock()
+		shard.items = make(map[K]V)
+		shard.mu.Unlock()
+	}
+}
+
+// PRIVATE HELPER FUNCTIONS BELOW
+
+func (m *ConcurrentMap[K, V]) shardFor(key K) *mapShard[K, V] {
+	return m.shards[m.hash(key)%uint64(len(m.shards))]
+}
+
+// hashes strings and integers directly and any other comparable key by walking its value with reflect
+func newKeyHasher[K comparable]() func(K) uint64 {
+	seed := maphash.MakeSeed()
+	return func(key K) uint64 {
+		switch k := any(key).(type) {
+		case string:
+			return maphash.String(seed, k)
+		case int:
+			return hashUint64(seed, uint64(k))
+		case int64:
+			return hashUint64(seed, uint64(k))
+		case int32:
+			return hashUint64(seed, uint64(k))
+		case uint:
+			return hashUint64(seed, uint64(k))
+		case uint64:
+			return hashUint64(seed, k)
+		case uint32:
+			return hashUint64(seed, uint64(k))
+		}
+		var h maphash.Hash
+		h.SetSeed(seed)
+		writeHashValue(&h, reflect.ValueOf(&key).Elem())
+		return h.Sum64()
+	}
+}
+
+func hashUint64(seed maphash.Seed, x uint64) uint64 {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], x)
+	return maphash.Bytes(seed, buf[:])
+}
+
+// writes the parts of v that == compares, so equal keys always get equal hashes
+func writeHashValue(h *maphash.Hash, v reflect.Value) {
+	var buf [8]byte
+	writeUint64 := func(x uint64) {
+		binary.LittleEndian.PutUint64(buf[:], x)
+		h.Write(buf[:])
+	}
+	writeFloat := func(f float64) {
+		// -0 == +0, so both must hash the same
+		if f == 0 {
+			f = 0
+		}
+		writeUint64(math.Float64bits(f))
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			h.WriteByte(1)
+		} else {
+			h.WriteByte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint64(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		writeFloat(real(c))
+		writeFloat(imag(c))
+	case reflect.String:
+		h.WriteString(v.String())
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		writeUint64(uint64(v.Pointer()))
+	case reflect.Interface:
+		if v.IsNil() {
+			h.WriteByte(0)
+		} else {
+			writeHashValue(h, v.Elem())
+		}
+	case reflect.Array:
+		for i := range v.Len() {
+			writeHashValue(h, v.Index(i))
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			writeHashValue(h, v.Field(i))
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package utils
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentMap_AsMap(t *testing.T) {
+	var m Map[int, string] = NewConcurrentMap[int, string]()
+	m.Put(1, "One")
+	m.Put(2, "Two")
+	m.Put(2, "NewTwo")
+	m.Remove(3)
+
+	if m.Get(2) != "NewTwo" || m.Get(3) != "" {
+		t.Errorf("Get returned the wrong values: %v", m.Values())
+	}
+	if !m.ContainsKey(1) || m.ContainsKey(3) {
+		t.Errorf("ContainsKey returned the wrong result")
+	}
+	keys := m.Keys()
+	sort.Ints(keys)
+	if !reflect.DeepEqual(keys, []int{1, 2}) || m.Size() != 2 {
+		t.Errorf("Expected keys [1 2], but got %v", keys)
+	}
+	m.Remove(1)
+	if m.Size() != 1 || len(m.Values()) != 1 {
+		t.Errorf("Expected size 1 after Remove, but got %d", m.Size())
+	}
+}
+
+func TestConcurrentMap_CustomHasher(t *testing.T) {
+	// A single shard and a constant hash must still behave like a map
+	m := NewConcurrentMapWithHasher[string, int](0, func(string) uint64 { return 7 })
+	for i := 0; i < 100; i++ {
+		m.Put(strconv.Itoa(i), i)
+	}
+	if len(m.shards) != 1 || m.Size() != 100 || m.Get("42") != 42 {
+		t.Errorf("Expected 100 mappings in a single shard")
+	}
+	m.Clear()
+	if m.Size() != 0 {
+		t.Errorf("Expected size 0 after Clear, but got %d", m.Size())
+	}
+}
+
+func TestConcurrentMap_DefaultHasher(t *testing.T) {
+	type point struct {
+		x, y float64
+		tag  interface{}
+	}
+	m := NewConcurrentMap[point, int]()
+	for i := 0; i < 1000; i++ {
+		m.Put(point{x: float64(i), y: -float64(i), tag: strconv.Itoa(i % 3)}, i)
+	}
+	// -0 equals +0, so both find the same mapping whichever shard it is in
+	if value, ok := m.GetOk(point{x: math.Copysign(0, -1), y: 0, tag: "0"}); !ok || value != 0 {
+		t.Errorf("Expected the key with -0 to find the mapping of +0, but got (%d, %v)", value, ok)
+	}
+	if m.Get(point{x: 500, y: -500, tag: "2"}) != 500 || m.ContainsKey(point{x: 500, y: -500, tag: "0"}) {
+		t.Errorf("Expected struct keys to be found by value")
+	}
+
+	used := 0
+	for _, shard := range m.shards {
+		if len(shard.items) > 0 {
+			used++
+		}
+	}
+	if used < len(m.shards)/2 {
+		t.Errorf("Expected the keys to spread over the shards, but only %d of %d are used", used, len(m.shards))
+	}
+}
+
+func TestConcurrentMap_Compute(t *testing.T) {
+	m := NewConcurrentMap[string, int]()
+
+	value, ok := m.ComputeIfAbsent("a", func() (int, bool) { return 1, true })
+	if !ok || value != 1 {
+		t.Errorf("Expected ComputeIfAbsent to add 1, but got %d (%v)", value, ok)
+	}
+	value, ok = m.ComputeIfAbsent("a", func() (int, bool) { return 2, true })
+	if !ok || value != 1 {
+		t.Errorf("Expected ComputeIfAbsent to keep 1, but got %d (%v)", value, ok)
+	}
+	if _, ok = m.ComputeIfAbsent("b", func() (int, bool) { return 0, false }); ok || m.ContainsKey("b") {
+		t.Errorf("Expected ComputeIfAbsent returning false to add nothing")
+	}
+
+	value, ok = m.ComputeIfPresent("a", func(old int) (int, bool) { return old + 10, true })
+	if !ok || value != 11 {
+		t.Errorf("Expected ComputeIfPresent to produce 11, but got %d (%v)", value, ok)
+	}
+	if _, ok = m.ComputeIfPresent("c", func(old int) (int, bool) { return 5, true }); ok || m.ContainsKey("c") {
+		t.Errorf("Expected ComputeIfPresent on a missing key to do nothing")
+	}
+	if _, ok = m.ComputeIfPresent("a", func(old int) (int, bool) { return old, false }); ok || m.ContainsKey("a") {
+		t.Errorf("Expected ComputeIfPresent returning false to remove the mapping")
+	}
+
+	value, ok = m.Compute("d", func(old int, exists bool) (int, bool) {
+		if exists {
+			t.Errorf("Expected 'd' to be missing")
+		}
+		return 4, true
+	})
+	if !ok || value != 4 || m.Get("d") != 4 {
+		t.Errorf("Expected Compute to add 4, but got %d (%v)", value, ok)
+	}
+}
+
+func TestConcurrentMap_ConcurrentUpdates(t *testing.T) {
+	m := NewConcurrentMap[int, int]()
+	sum := func(a, b int) int { return a + b }
+	goroutines := 8
+	perGoroutine := 2000
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				key := i % 50
+				m.Merge(key, 1, sum)
+				m.Compute(-1, func(old int, _ bool) (int, bool) { return old + 1, true })
+				m.Get(key)
+				m.Keys()
+			}
+		}()
+	}
+	wg.Wait()
+
+	total := 0
+	for _, k := range m.Keys() {
+		if k >= 0 {
+			total += m.Get(k)
+		}
+	}
+	if total != goroutines*perGoroutine {
+		t.Errorf("Expected Merge to count %d updates, but got %d", goroutines*perGoroutine, total)
+	}
+	if m.Get(-1) != goroutines*perGoroutine {
+		t.Errorf("Expected Compute to count %d updates, but got %d", goroutines*perGoroutine, m.Get(-1))
+	}
+}
+
+const benchmarkKeyCount = 1024
+
+func BenchmarkConcurrentMap_ReadMostly(b *testing.B) {
+	m := NewConcurrentMap[int, int]()
+	for i := 0; i < benchmarkKeyCount; i++ {
+		m.Put(i, i)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if i%10 == 0 {
+				m.Put(i%benchmarkKeyCount, i)
+			} else {
+				m.Get(i % benchmarkKeyCount)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkSyncMap_ReadMostly(b *testing.B) {
+	var m sync.Map
+	for i := 0; i < benchmarkKeyCount; i++ {
+		m.Store(i, i)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if i%10 == 0 {
+				m.Store(i%benchmarkKeyCount, i)
+			} else {
+				m.Load(i % benchmarkKeyCount)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkConcurrentMap_WriteHeavy(b *testing.B) {
+	m := NewConcurrentMap[int, int]()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			m.Put(i%benchmarkKeyCount, i)
+			i++
+		}
+	})
+}
+
+func BenchmarkSyncMap_WriteHeavy(b *testing.B) {
+	var m sync.Map
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			m.Store(i%benchmarkKeyCount, i)
+			i++
+		}
+	})
+}
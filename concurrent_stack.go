@@ -0,0 +1,166 @@
+package utils
+
+import "sync/atomic"
+
+/*
+A lock-free implementation of the Stack interface that is safe for concurrent use
+This is a Treiber stack: the top is an atomic pointer to an immutable linked node,
+and every change swaps the top with compare-and-swap.
+Since nodes are never modified, every method works on a single loaded top and is linearizable:
+Size, ToSlice and PopAll reflect the stack exactly as it was at one instant,
+although the stack may have changed by the time they return
+*/
+type ConcurrentStack[T interface{}] struct {
+	top atomic.Pointer[stackNode[T]]
+}
+
+type stackNode[T interface{}] struct {
+	item T
+	next *stackNode[T]
+	// number of nodes from this node to the bottom
+	depth int
+}
+
+/*
+O(1)
+Instantiates a new empty ConcurrentStack
+*/
+func NewConcurrentStack[T interface{}]() *ConcurrentStack[T] {
+	return &ConcurrentStack[T]{}
+}
+
+/*
+O(1) without contention
+Assumes: the stack has been instantiated
+Places the item on top of the stack
+*/
+func (s *ConcurrentStack[T]) Push(item T) {
+	node := &stackNode[T]{item: item}
+	for {
+		top := s.top.Load()
+		node.next = top
+		node.depth = depthOf(top) + 1
+		if s.top.CompareAndSwap(top, node) {
+			return
+		}
+	}
+}
+
+/*
+O(k) without contention, where k is the number of items
+Assumes: the stack has been instantiated
+Atomically pushes the items in order, so the last item ends up on top
+Other goroutines never see only some of the items
+*/
+func (s *ConcurrentStack[T]) PushAll(items []T) {
+	if len(items) == 0 {
+		return
+	}
+	for {
+		top := s.top.Load()
+		node := top
+		for _, item := range items {
+			node = &stackNode[T]{item: item, next: node, depth: depthOf(node) + 1}
+		}
+		if s.top.CompareAndSwap(top, node) {
+			return
+		}
+	}
+}
+
+/*
+O(1) without contention
+Assumes: the stack has been instantiated
+Removes the item on top of the stack and returns it
+Returns error if the stack is empty
+*/
+func (s *ConcurrentStack[T]) Pop() (T, error) {
+	for {
+		top := s.top.Load()
+		if top == nil {
+			var nilVal T
+			return nilVal, &OpError{Op: "ConcurrentStack.Pop", Err: ErrEmpty}
+		}
+		if s.top.CompareAndSwap(top, top.next) {
+			return top.item, nil
+		}
+	}
+}
+
+/*
+O(n)
+Assumes: the stack has been instantiated
+Atomically empties the stack and returns the items in the order they would have been popped
+*/
+func (s *ConcurrentStack[T]) PopAll() []T {
+	top := s.top.Swap(nil)
+	out := make([]T, 0, depthOf(top))
+	for node := top; node != nil; node = node.next {
+		out = append(out, node.item)
+	}
+	return out
+}
+
+/*
+O(1)
+Assumes: the stack has been instantiated
+Returns the item on top of the stack without removing it
+Returns error if the stack is empty
+*/
+func (s *ConcurrentStack[T]) Peek() (T, error) {
+	top := s.top.Load()
+	if top == nil {
+		var nilVal T
+		return nilVal, &OpError{Op: "ConcurrentStack.Peek", Err: ErrEmpty}
+	}
+	return top.item, nil
+}
+
+/*
+O(1)
+Assumes: the stack has been instantiated
+Returns the number of items at the instant the top was read
+*/
+func (s *ConcurrentStack[T]) Size() int {
+	return depthOf(s.top.Load())
+}
+
+/*
+O(1)
+Assumes: the stack has been instantiated
+Returns true if the stack was empty at the instant the top was read
+*/
+func (s *ConcurrentStack[T]) IsEmpty() bool {
+	return s.top.Load() == nil
+}
+
+/*
+O(n)
+Assumes: the stack has been instantiated
+Returns a snapshot of the stack from the bottom to the top, like SliceStack.ToSlice
+*/
+func (s *ConcurrentStack[T]) ToSlice() []T {
+	top := s.top.Load()
+	out := make([]T, depthOf(top))
+	for node := top; node != nil; node = node.next {
+		out[node.depth-1] = node.item
+	}
+	return out
+}
+
+/*
+O(1)
+Atomically empties the stack
+*/
+func (s *ConcurrentStack[T]) Clear() {
+	s.top.Store(nil)
+}
+
+// PRIVATE HELPER FUNCTIONS BELOW
+
+func depthOf[T interface{}](node *stackNode[T]) int {
+	if node == nil {
+		return 0
+	}
+	return node.depth
+}
@@ -0,0 +1,125 @@
+package utils
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentStack_AsStack(t *testing.T) {
+	var s Stack[int] = NewConcurrentStack[int]()
+	s.PushAll([]int{1, 2, 3})
+	s.Push(4)
+
+	if s.Size() != 4 {
+		t.Errorf("Expected size 4, but got %d", s.Size())
+	}
+	if items := s.ToSlice(); !reflect.DeepEqual(items, []int{1, 2, 3, 4}) {
+		t.Errorf("Expected ToSlice to return [1 2 3 4], but got %v", items)
+	}
+	top, _ := s.Peek()
+	item, _ := s.Pop()
+	if top != 4 || item != 4 {
+		t.Errorf("Expected Peek and Pop to return 4, but got %d and %d", top, item)
+	}
+	if items := s.PopAll(); !reflect.DeepEqual(items, []int{3, 2, 1}) {
+		t.Errorf("Expected PopAll to return [3 2 1], but got %v", items)
+	}
+	if !s.IsEmpty() {
+		t.Errorf("Expected the stack to be empty after PopAll")
+	}
+	if _, err := s.Pop(); err == nil {
+		t.Errorf("Pop should return an error when the stack is empty")
+	}
+	if _, err := s.Peek(); err == nil {
+		t.Errorf("Peek should return an error when the stack is empty")
+	}
+
+	s.PushAll([]int{1, 2})
+	s.Clear()
+	if s.Size() != 0 {
+		t.Errorf("Expected size 0 after Clear, but got %d", s.Size())
+	}
+}
+
+func TestConcurrentStack_ConcurrentPushAndPop(t *testing.T) {
+	s := NewConcurrentStack[int]()
+	goroutines := 8
+	perGoroutine := 5000
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	popped := make([]int, 0, goroutines*perGoroutine)
+	for g := 0; g < goroutines; g++ {
+		wg.Add(2)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				s.Push(g*perGoroutine + i)
+			}
+		}(g)
+		go func() {
+			defer wg.Done()
+			local := make([]int, 0)
+			for i := 0; i < perGoroutine; i++ {
+				if item, err := s.Pop(); err == nil {
+					local = append(local, item)
+				}
+			}
+			mu.Lock()
+			popped = append(popped, local...)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	popped = append(popped, s.PopAll()...)
+	if len(popped) != goroutines*perGoroutine {
+		t.Fatalf("Expected %d items, but got %d", goroutines*perGoroutine, len(popped))
+	}
+	sort.Ints(popped)
+	for i, item := range popped {
+		if item != i {
+			t.Fatalf("Expected every item exactly once, but found %d at position %d", item, i)
+		}
+	}
+}
+
+func TestConcurrentStack_SnapshotsAreConsistent(t *testing.T) {
+	s := NewConcurrentStack[int]()
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+
+	// Writers only ever push and pop whole batches of [0 1 2 3]
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					s.PushAll([]int{0, 1, 2, 3})
+					s.Pop()
+					s.Pop()
+					s.Pop()
+					s.Pop()
+				}
+			}
+		}()
+	}
+
+	// A snapshot must always be made of whole batches, never of half applied pushes
+	for i := 0; i < 2000; i++ {
+		items := s.ToSlice()
+		for j, item := range items {
+			if j > 0 && item != 0 && items[j-1] != item-1 {
+				t.Fatalf("Snapshot %v is not made of ordered batches", items)
+			}
+		}
+	}
+	close(stop)
+	wg.Wait()
+}
@@ -0,0 +1,244 @@
+package utils
+
+import "iter"
+
+/*
+A multiset that counts how many times every item has been added
+Only positive counts are stored, an item whose count drops to zero is removed
+*/
+type Counter[T comparable] struct {
+	counts map[T]int
+	total  int
+}
+
+/*
+An item of a Counter together with its count
+*/
+type CountEntry[T comparable] struct {
+	Item  T
+	Count int
+}
+
+/*
+O(1)
+Instantiates a new empty Counter
+*/
+func NewCounter[T comparable]() *Counter[T] {
+	return &Counter[T]{counts: make(map[T]int)}
+}
+
+/*
+O(n)
+Instantiates a new Counter that counts the items of the iterator
+*/
+func CollectCounter[T comparable](seq iter.Seq[T]) *Counter[T] {
+	c := NewCounter[T]()
+	for item := range seq {
+		c.Add(item, 1)
+	}
+	return c
+}
+
+/*
+O(1)
+Assumes: Counter c has been instantiated
+Adds the item n times and returns its new count
+A non-positive n is a no-op
+*/
+func (c *Counter[T]) Add(item T, n int) int {
+	if n <= 0 {
+		return c.counts[item]
+	}
+	c.counts[item] += n
+	c.total += n
+	return c.counts[item]
+}
+
+/*
+O(m) where m is the number of items
+Assumes: Counter c has been instantiated
+Adds every item once
+*/
+func (c *Counter[T]) AddAll(items []T) {
+	for _, item := range items {
+		c.Add(item, 1)
+	}
+}
+
+/*
+O(1)
+Assumes: Counter c has been instantiated
+Removes the item up to n times
+Returns how many times it was removed, which is less than n if the count was smaller
+*/
+func (c *Counter[T]) Remove(item T, n int) int {
+	count := c.counts[item]
+	if n <= 0 || count == 0 {
+		return 0
+	}
+	removed := min(n, count)
+	if removed == count {
+		delete(c.counts, item)
+	} else {
+		c.counts[item] = count - removed
+	}
+	c.total -= removed
+	return removed
+}
+
+/*
+O(1)
+Assumes: Counter c has been instantiated
+Returns how many times the item has been added, 0 if it is not in the counter
+*/
+func (c Counter[T]) Count(item T) int {
+	return c.counts[item]
+}
+
+/*
+O(1)
+Assumes: Counter c has been instantiated
+Returns true if the item has a positive count
+*/
+func (c Counter[T]) Contains(item T) bool {
+	return c.counts[item] > 0
+}
+
+/*
+O(1)
+Assumes: Counter c has been instantiated
+Returns the sum of all counts
+*/
+func (c Counter[T]) Total() int {
+	return c.total
+}
+
+/*
+O(1)
+Assumes: Counter c has been instantiated
+Returns the number of distinct items
+*/
+func (c Counter[T]) Size() int {
+	return len(c.counts)
+}
+
+/*
+O(n)
+Assumes: Counter c has been instantiated
+Returns the distinct items in no particular order
+*/
+func (c Counter[T]) Items() []T {
+	items := make([]T, 0, len(c.counts))
+	for item := range c.counts {
+		items = append(items, item)
+	}
+	return items
+}
+
+/*
+O(1)
+Assumes: Counter c has been instantiated
+Removes every item
+*/
+func (c *Counter[T]) Clear() {
+	c.counts = make(map[T]int)
+	c.total = 0
+}
+
+/*
+O(n log k)
+Assumes: Counter c has been instantiated
+Returns the k items with the highest counts, from the highest to the lowest count
+Returns every item if k is larger than the number of items. The order of equal counts is unspecified
+*/
+func (c Counter[T]) MostCommon(k int) []CountEntry[T] {
+	if k <= 0 {
+		return []CountEntry[T]{}
+	}
+	top := NewBoundedPriorityQueue(k, func(a, b CountEntry[T]) int {
+		return b.Count - a.Count
+	})
+	for item, count := range c.counts {
+		top.Offer(CountEntry[T]{Item: item, Count: count})
+	}
+	return top.Sorted()
+}
+
+/*
+O(n + m)
+Assumes: Counters c and other have been instantiated
+Returns a new Counter where every count is the sum of the counts
+*/
+func (c Counter[T]) Plus(other *Counter[T]) *Counter[T] {
+	out := c.clone()
+	for item, count := range other.counts {
+		out.Add(item, count)
+	}
+	return out
+}
+
+/*
+O(n + m)
+Assumes: Counters c and other have been instantiated
+Returns a new Counter where every count is the count in c minus the count in other
+Items whose count is not positive are left out
+*/
+func (c Counter[T]) Minus(other *Counter[T]) *Counter[T] {
+	out := c.clone()
+	for item, count := range other.counts {
+		out.Remove(item, count)
+	}
+	return out
+}
+
+/*
+O(n)
+Assumes: Counters c and other have been instantiated
+Returns a new Counter where every count is the smaller of the counts
+*/
+func (c Counter[T]) Intersect(other *Counter[T]) *Counter[T] {
+	out := NewCounter[T]()
+	for item, count := range c.counts {
+		out.Add(item, min(count, other.counts[item]))
+	}
+	return out
+}
+
+/*
+O(n + m)
+Assumes: Counters c and other have been instantiated
+Returns a new Counter where every count is the larger of the counts
+*/
+func (c Counter[T]) Union(other *Counter[T]) *Counter[T] {
+	out := c.clone()
+	for item, count := range other.counts {
+		out.Add(item, count-out.counts[item])
+	}
+	return out
+}
+
+/*
+O(1)
+Assumes: Counter c has been instantiated
+Returns an iterator over the items and their counts in no particular order
+The counter must not be modified during the iteration
+*/
+func (c Counter[T]) All() iter.Seq2[T, int] {
+	return func(yield func(T, int) bool) {
+		for item, count := range c.counts {
+			if !yield(item, count) {
+				return
+			}
+		}
+	}
+}
+
+// PRIVATE HELPER FUNCTIONS BELOW
+
+func (c Counter[T]) clone() *Counter[T] {
+	out := &Counter[T]{counts: make(map[T]int, len(c.counts)), total: c.total}
+	for item, count := range c.counts {
+		out.counts[item] = count
+	}
+	return out
+}
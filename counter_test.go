@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCounter_AddAndRemove(t *testing.T) {
+	c := CollectCounter(slices.Values(strings.Fields("to be or not to be")))
+	if c.Count("to") != 2 || c.Count("or") != 1 || c.Count("is") != 0 {
+		t.Errorf("Unexpected counts: %v", c.counts)
+	}
+	if c.Total() != 6 || c.Size() != 4 {
+		t.Errorf("Expected total 6 and size 4, but got %d and %d", c.Total(), c.Size())
+	}
+
+	if count := c.Add("be", 3); count != 5 {
+		t.Errorf("Expected count 5, but got %d", count)
+	}
+	if count := c.Add("be", -1); count != 5 {
+		t.Errorf("Expected a negative Add to be a no-op, but got %d", count)
+	}
+	if removed := c.Remove("to", 5); removed != 2 {
+		t.Errorf("Expected to remove 2, but removed %d", removed)
+	}
+	if c.Contains("to") || c.Size() != 3 {
+		t.Errorf("Expected 'to' to be gone when its count reached zero")
+	}
+	if removed := c.Remove("missing", 1); removed != 0 {
+		t.Errorf("Expected to remove nothing, but removed %d", removed)
+	}
+	if c.Total() != 7 {
+		t.Errorf("Expected total 7, but got %d", c.Total())
+	}
+
+	c.Clear()
+	if c.Total() != 0 || c.Size() != 0 {
+		t.Errorf("Expected an empty counter after Clear")
+	}
+}
+
+func TestCounter_MostCommon(t *testing.T) {
+	c := NewCounter[string]()
+	c.Add("a", 5)
+	c.Add("b", 1)
+	c.Add("c", 3)
+	c.Add("d", 4)
+
+	expected := []CountEntry[string]{{"a", 5}, {"d", 4}, {"c", 3}}
+	if top := c.MostCommon(3); !reflect.DeepEqual(top, expected) {
+		t.Errorf("Expected %v, but got %v", expected, top)
+	}
+	if top := c.MostCommon(10); len(top) != 4 || top[3].Item != "b" {
+		t.Errorf("Expected every item when k is larger than the size, but got %v", top)
+	}
+	if top := c.MostCommon(0); len(top) != 0 {
+		t.Errorf("Expected no items for k = 0, but got %v", top)
+	}
+}
+
+func TestCounter_Arithmetic(t *testing.T) {
+	a := NewCounter[string]()
+	a.Add("x", 3)
+	a.Add("y", 1)
+	b := NewCounter[string]()
+	b.Add("x", 1)
+	b.Add("y", 2)
+	b.Add("z", 4)
+
+	check := func(name string, c *Counter[string], expected map[string]int) {
+		total := 0
+		for _, count := range expected {
+			total += count
+		}
+		if !reflect.DeepEqual(c.counts, expected) || c.Total() != total {
+			t.Errorf("%s: expected %v with total %d, but got %v with total %d", name, expected, total, c.counts, c.Total())
+		}
+	}
+	check("Plus", a.Plus(b), map[string]int{"x": 4, "y": 3, "z": 4})
+	check("Minus", a.Minus(b), map[string]int{"x": 2})
+	check("Intersect", a.Intersect(b), map[string]int{"x": 1, "y": 1})
+	check("Union", a.Union(b), map[string]int{"x": 3, "y": 2, "z": 4})
+	// The operands are left unchanged
+	check("a", a, map[string]int{"x": 3, "y": 1})
+}
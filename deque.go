@@ -0,0 +1,451 @@
+package utils
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+)
+
+/*
+A double-ended queue backed by a growable circular buffer
+Satisfies both the Stack and the Queue interface:
+the top of the stack and the front of the queue are both the front of the deque,
+so Push/Pop work on the front while Enqueue works on the back and Dequeue on the front.
+ToSlice and the iterators always go from the front to the back, which is queue order but top to bottom for a stack,
+the reverse of SliceStack. Use AsStack where a stack that lists its items from the bottom to the top is expected
+The zero value is an empty deque ready to use
+*/
+type Deque[T interface{}] struct {
+	contents ring[T]
+}
+
+/*
+A live Stack view of a Deque, with its top at the front of the deque
+Unlike the deque itself, ToSlice and the iterators go from the bottom to the top like SliceStack
+*/
+type DequeStack[T interface{}] struct {
+	deque *Deque[T]
+}
+
+/*
+O(1)
+Instantiates a new empty Deque
+*/
+func NewDeque[T interface{}]() *Deque[T] {
+	return &Deque[T]{contents: *newRing[T](defaultRingCapacity)}
+}
+
+/*
+O(capacity)
+Instantiates a new Deque with room for capacity items before it has to grow
+The deque never shrinks below this capacity
+*/
+func NewDequeWithCapacity[T interface{}](capacity int) *Deque[T] {
+	return &Deque[T]{contents: *newRing[T](capacity)}
+}
+
+/*
+O(1) amortized
+Assumes: the deque has been instantiated
+Places the item at the front of the deque
+*/
+func (d *Deque[T]) PushFront(item T) {
+	d.contents.pushFront(item)
+}
+
+/*
+O(1) amortized
+Assumes: the deque has been instantiated
+Places the item at the back of the deque
+*/
+func (d *Deque[T]) PushBack(item T) {
+	d.contents.pushBack(item)
+}
+
+/*
+O(1) amortized
+Assumes: the deque has been instantiated
+Removes the item at the front of the deque and returns it
+Returns error if the deque is empty
+*/
+func (d *Deque[T]) PopFront() (T, error) {
+	var nilVal T
+	if d.contents.size == 0 {
+		return nilVal, &OpError{Op: "Deque.PopFront", Err: ErrEmpty}
+	}
+	return d.contents.popFront(), nil
+}
+
+/*
+O(1) amortized
+Assumes: the deque has been instantiated
+Removes the item at the back of the deque and returns it
+Returns error if the deque is empty
+*/
+func (d *Deque[T]) PopBack() (T, error) {
+	var nilVal T
+	if d.contents.size == 0 {
+		return nilVal, &OpError{Op: "Deque.PopBack", Err: ErrEmpty}
+	}
+	return d.contents.popBack(), nil
+}
+
+/*
+O(1)
+Assumes: the deque has been instantiated
+Returns the item at the front of the deque without removing it
+Returns error if the deque is empty
+*/
+func (d Deque[T]) PeekFront() (T, error) {
+	var nilVal T
+	if d.contents.size == 0 {
+		return nilVal, &OpError{Op: "Deque.PeekFront", Err: ErrEmpty}
+	}
+	return d.contents.at(0), nil
+}
+
+/*
+O(1)
+Assumes: the deque has been instantiated
+Returns the item at the back of the deque without removing it
+Returns error if the deque is empty
+*/
+func (d Deque[T]) PeekBack() (T, error) {
+	var nilVal T
+	if d.contents.size == 0 {
+		return nilVal, &OpError{Op: "Deque.PeekBack", Err: ErrEmpty}
+	}
+	return d.contents.at(d.contents.size - 1), nil
+}
+
+/*
+O(1)
+Assumes: the deque has been instantiated
+Returns the i:th item counted from the front, where 0 is the front
+Returns error if i is out of range
+*/
+func (d Deque[T]) At(i int) (T, error) {
+	var nilVal T
+	if i < 0 || i >= d.contents.size {
+		return nilVal, &OpError{Op: "Deque.At", Err: fmt.Errorf("%w: %d for size %d", ErrOutOfRange, i, d.contents.size)}
+	}
+	return d.contents.at(i), nil
+}
+
+/*
+O(1) amortized
+Assumes: the deque has been instantiated
+Stack operation: places the item on top of the stack, which is the front of the deque
+*/
+func (d *Deque[T]) Push(item T) {
+	d.contents.pushFront(item)
+}
+
+/*
+O(n)
+Assumes: the deque has been instantiated
+Stack operation: pushes the items in order, so the last item ends up at the front
+*/
+func (d *Deque[T]) PushAll(items []T) {
+	for _, item := range items {
+		d.contents.pushFront(item)
+	}
+}
+
+/*
+O(1) amortized
+Assumes: the deque has been instantiated
+Stack operation: removes the item at the front of the deque and returns it
+Returns error if the deque is empty
+*/
+func (d *Deque[T]) Pop() (T, error) {
+	var nilVal T
+	if d.contents.size == 0 {
+		return nilVal, &OpError{Op: "Deque.Pop", Err: ErrEmpty}
+	}
+	return d.contents.popFront(), nil
+}
+
+/*
+O(n)
+Assumes: the deque has been instantiated
+Stack operation: removes all items and returns them in the order they would have been popped
+*/
+func (d *Deque[T]) PopAll() []T {
+	out := d.contents.toSlice()
+	d.contents.clear()
+	return out
+}
+
+/*
+O(1) amortized
+Assumes: the deque has been instantiated
+Queue operation: places the item at the back of the deque
+*/
+func (d *Deque[T]) Enqueue(item T) {
+	d.contents.pushBack(item)
+}
+
+/*
+O(n)
+Assumes: the deque has been instantiated
+Queue operation: places each item at the back of the deque
+*/
+func (d *Deque[T]) EnqueueAll(items []T) {
+	for _, item := range items {
+		d.contents.pushBack(item)
+	}
+}
+
+/*
+O(1) amortized
+Assumes: the deque has been instantiated
+Queue operation: removes the item at the front of the deque and returns it
+Returns error if the deque is empty
+*/
+func (d *Deque[T]) Dequeue() (T, error) {
+	var nilVal T
+	if d.contents.size == 0 {
+		return nilVal, &OpError{Op: "Deque.Dequeue", Err: ErrEmpty}
+	}
+	return d.contents.popFront(), nil
+}
+
+/*
+O(n)
+Assumes: the deque has been instantiated
+Queue operation: removes all items and returns them from front to back
+*/
+func (d *Deque[T]) DequeueAll() []T {
+	out := d.contents.toSlice()
+	d.contents.clear()
+	return out
+}
+
+/*
+O(1)
+Assumes: the deque has been instantiated
+Returns the item at the front of the deque without removing it,
+which is both the top of the stack and the front of the queue
+Returns error if the deque is empty
+*/
+func (d Deque[T]) Peek() (T, error) {
+	var nilVal T
+	if d.contents.size == 0 {
+		return nilVal, &OpError{Op: "Deque.Peek", Err: ErrEmpty}
+	}
+	return d.contents.at(0), nil
+}
+
+/*
+O(1)
+Assumes: the deque has been instantiated
+Returns true if there are no items in the deque
+*/
+func (d Deque[T]) IsEmpty() bool {
+	return d.contents.size == 0
+}
+
+/*
+O(1)
+Assumes: the deque has been instantiated
+Returns the number of items in the deque
+*/
+func (d Deque[T]) Size() int {
+	return d.contents.size
+}
+
+/*
+O(1)
+Replaces the contents with an empty buffer of the initial capacity
+*/
+func (d *Deque[T]) Clear() {
+	d.contents.clear()
+}
+
+/*
+O(n)
+Assumes: the deque has been instantiated
+Returns the items from front to back as a slice
+*/
+func (d Deque[T]) ToSlice() []T {
+	return d.contents.toSlice()
+}
+
+/*
+O(1)
+Assumes: the deque has been instantiated
+Returns an iterator over the positions and items from the front to the back
+The deque must not be modified during the iteration
+*/
+func (d Deque[T]) All() iter.Seq2[int, T] {
+	return d.contents.all()
+}
+
+/*
+O(1)
+Assumes: the deque has been instantiated
+Returns an iterator over the items from the front to the back
+*/
+func (d Deque[T]) Values() iter.Seq[T] {
+	return d.contents.values()
+}
+
+/*
+O(1)
+Assumes: the deque has been instantiated
+Returns an iterator over the positions and items from the back to the front
+*/
+func (d Deque[T]) Backward() iter.Seq2[int, T] {
+	return d.contents.backward()
+}
+
+/*
+O(1)
+Returns a Stack view of the deque that lists its items from the bottom to the top
+Changes through the view are changes to the deque and the other way around
+*/
+func (d *Deque[T]) AsStack() *DequeStack[T] {
+	return &DequeStack[T]{deque: d}
+}
+
+/*
+O(1) amortized
+Places the item on top of the stack, which is the front of the deque
+*/
+func (s DequeStack[T]) Push(item T) {
+	s.deque.contents.pushFront(item)
+}
+
+/*
+O(n)
+Pushes the items in order, so the last item ends up on top
+*/
+func (s DequeStack[T]) PushAll(items []T) {
+	s.deque.PushAll(items)
+}
+
+/*
+O(1) amortized
+Removes the top item and returns it
+Returns error if the stack is empty
+*/
+func (s DequeStack[T]) Pop() (T, error) {
+	var nilVal T
+	if s.deque.contents.size == 0 {
+		return nilVal, &OpError{Op: "DequeStack.Pop", Err: ErrEmpty}
+	}
+	return s.deque.contents.popFront(), nil
+}
+
+/*
+O(n)
+Removes all items and returns them in the order they would have been popped
+*/
+func (s DequeStack[T]) PopAll() []T {
+	return s.deque.PopAll()
+}
+
+/*
+O(1)
+Returns the top item without removing it
+Returns error if the stack is empty
+*/
+func (s DequeStack[T]) Peek() (T, error) {
+	var nilVal T
+	if s.deque.contents.size == 0 {
+		return nilVal, &OpError{Op: "DequeStack.Peek", Err: ErrEmpty}
+	}
+	return s.deque.contents.at(0), nil
+}
+
+/*
+O(1)
+Returns the number of items
+*/
+func (s DequeStack[T]) Size() int {
+	return s.deque.contents.size
+}
+
+/*
+O(1)
+Returns true if the stack is empty
+*/
+func (s DequeStack[T]) IsEmpty() bool {
+	return s.deque.contents.size == 0
+}
+
+/*
+O(n)
+Returns the items from the bottom to the top as a slice, the same order as SliceStack
+*/
+func (s DequeStack[T]) ToSlice() []T {
+	out := s.deque.contents.toSlice()
+	slices.Reverse(out)
+	return out
+}
+
+/*
+O(1)
+Removes every item from the deque
+*/
+func (s DequeStack[T]) Clear() {
+	s.deque.contents.clear()
+}
+
+/*
+O(1)
+Returns an iterator over the positions and items from the bottom to the top, the order of ToSlice
+The deque must not be modified during the iteration
+*/
+func (s DequeStack[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		contents := &s.deque.contents
+		for i := 0; i < contents.size; i++ {
+			if !yield(i, contents.at(contents.size-1-i)) {
+				return
+			}
+		}
+	}
+}
+
+/*
+O(1)
+Returns an iterator over the items from the bottom to the top
+*/
+func (s DequeStack[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range s.All() {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+/*
+O(1)
+Returns an iterator over the positions and items from the top to the bottom, which is the order they would be popped
+*/
+func (s DequeStack[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		contents := &s.deque.contents
+		for i := 0; i < contents.size; i++ {
+			if !yield(contents.size-1-i, contents.at(i)) {
+				return
+			}
+		}
+	}
+}
+
+/*
+O(n)
+Instantiates a new Deque and pushes the items of the iterator to the back in order
+*/
+func CollectDeque[T interface{}](seq iter.Seq[T]) *Deque[T] {
+	d := NewDeque[T]()
+	for item := range seq {
+		d.PushBack(item)
+	}
+	return d
+}
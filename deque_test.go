@@ -0,0 +1,226 @@
+package utils
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestDeque_PushAndPopBothEnds(t *testing.T) {
+	d := NewDeque[int]()
+	d.PushBack(2)
+	d.PushBack(3)
+	d.PushFront(1)
+	d.PushFront(0)
+
+	expected := []int{0, 1, 2, 3}
+	if !reflect.DeepEqual(d.ToSlice(), expected) {
+		t.Errorf("Expected %v, but got %v", expected, d.ToSlice())
+	}
+
+	front, _ := d.PopFront()
+	back, _ := d.PopBack()
+	if front != 0 || back != 3 {
+		t.Errorf("Expected front 0 and back 3, but got %d and %d", front, back)
+	}
+
+	front, _ = d.PeekFront()
+	back, _ = d.PeekBack()
+	if front != 1 || back != 2 {
+		t.Errorf("Expected front 1 and back 2, but got %d and %d", front, back)
+	}
+}
+
+func TestDeque_EmptyErrors(t *testing.T) {
+	d := NewDeque[int]()
+	if _, err := d.PopFront(); err == nil {
+		t.Errorf("PopFront should return an error when the deque is empty")
+	}
+	if _, err := d.PopBack(); err == nil {
+		t.Errorf("PopBack should return an error when the deque is empty")
+	}
+	if _, err := d.PeekFront(); err == nil {
+		t.Errorf("PeekFront should return an error when the deque is empty")
+	}
+	if _, err := d.PeekBack(); err == nil {
+		t.Errorf("PeekBack should return an error when the deque is empty")
+	}
+	if _, err := d.At(0); err == nil {
+		t.Errorf("At should return an error when the index is out of range")
+	}
+}
+
+func TestDeque_At(t *testing.T) {
+	d := NewDequeWithCapacity[int](4)
+	// Push to the front past the start of the buffer so the contents wrap around
+	for i := 5; i >= 0; i-- {
+		d.PushFront(i)
+	}
+	for i := 0; i < 6; i++ {
+		item, err := d.At(i)
+		if err != nil || item != i {
+			t.Errorf("Expected %d at index %d, but got %d (err: %v)", i, i, item, err)
+		}
+	}
+	if _, err := d.At(-1); err == nil {
+		t.Errorf("At should return an error for a negative index")
+	}
+	if _, err := d.At(6); err == nil {
+		t.Errorf("At should return an error for an index equal to the size")
+	}
+}
+
+func TestDeque_AsStack(t *testing.T) {
+	var s Stack[int] = NewDeque[int]()
+	s.PushAll([]int{1, 2, 3})
+	top, _ := s.Peek()
+	if top != 3 {
+		t.Errorf("Expected top 3, but got %d", top)
+	}
+	item, _ := s.Pop()
+	if item != 3 {
+		t.Errorf("Expected 3, but got %d", item)
+	}
+	rest := s.PopAll()
+	expected := []int{2, 1}
+	if !reflect.DeepEqual(rest, expected) {
+		t.Errorf("Expected %v, but got %v", expected, rest)
+	}
+	if !s.IsEmpty() {
+		t.Errorf("Stack should be empty after PopAll")
+	}
+}
+
+func TestDeque_StackView(t *testing.T) {
+	d := NewDeque[int]()
+	s := d.AsStack()
+	s.PushAll([]int{1, 2, 3})
+
+	// The deque lists the top first, the view lists the bottom first like SliceStack
+	if items := d.ToSlice(); !reflect.DeepEqual(items, []int{3, 2, 1}) {
+		t.Errorf("Expected the deque to list [3 2 1], but got %v", items)
+	}
+	if items := s.ToSlice(); !reflect.DeepEqual(items, []int{1, 2, 3}) {
+		t.Errorf("Expected the view to list [1 2 3], but got %v", items)
+	}
+	if items := slices.Collect(s.Values()); !reflect.DeepEqual(items, []int{1, 2, 3}) {
+		t.Errorf("Expected Values to go from the bottom to the top, but got %v", items)
+	}
+	for i, v := range s.All() {
+		if v != i+1 {
+			t.Errorf("Expected item %d at position %d, but got %d", i+1, i, v)
+		}
+	}
+	backward := make([]int, 0)
+	for i, v := range s.Backward() {
+		if v != i+1 {
+			t.Errorf("Expected item %d at position %d, but got %d", i+1, i, v)
+		}
+		backward = append(backward, v)
+	}
+	if !reflect.DeepEqual(backward, []int{3, 2, 1}) {
+		t.Errorf("Expected Backward to go in pop order, but got %v", backward)
+	}
+
+	// The view is live in both directions
+	d.PushFront(4)
+	if top, _ := s.Peek(); top != 4 || s.Size() != 4 {
+		t.Errorf("Expected the view to see the item pushed to the deque")
+	}
+	s.Pop()
+	if front, _ := d.PeekFront(); front != 3 {
+		t.Errorf("Expected the deque to see the item popped from the view, but got front %d", front)
+	}
+}
+
+func TestDeque_ZeroValue(t *testing.T) {
+	var d Deque[int]
+	if !d.IsEmpty() || len(d.ToSlice()) != 0 {
+		t.Errorf("Expected the zero value to be empty")
+	}
+	if _, err := d.PopBack(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected PopBack on the zero value to wrap ErrEmpty, but got %v", err)
+	}
+	d.PushBack(2)
+	d.PushFront(1)
+	d.Enqueue(3)
+	if items := d.ToSlice(); !reflect.DeepEqual(items, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], but got %v", items)
+	}
+	d.Clear()
+	d.Push(1)
+	if item, err := d.Pop(); err != nil || item != 1 || !d.IsEmpty() {
+		t.Errorf("Expected the zero value to keep working after Clear")
+	}
+}
+
+func TestDeque_AsQueue(t *testing.T) {
+	var q Queue[int] = NewDeque[int]()
+	q.EnqueueAll([]int{1, 2, 3})
+	front, _ := q.Peek()
+	if front != 1 {
+		t.Errorf("Expected front 1, but got %d", front)
+	}
+	item, _ := q.Dequeue()
+	if item != 1 {
+		t.Errorf("Expected 1, but got %d", item)
+	}
+	rest := q.DequeueAll()
+	expected := []int{2, 3}
+	if !reflect.DeepEqual(rest, expected) {
+		t.Errorf("Expected %v, but got %v", expected, rest)
+	}
+	if _, err := q.Dequeue(); err == nil {
+		t.Errorf("Dequeue should return an error when the queue is empty")
+	}
+}
+
+func TestDeque_SlidingWindowMaximum(t *testing.T) {
+	// Monotonic deque of indices, the classic sliding window use case
+	nums := []int{1, 3, -1, -3, 5, 3, 6, 7}
+	k := 3
+	d := NewDeque[int]()
+	out := make([]int, 0)
+	for i, n := range nums {
+		if front, err := d.PeekFront(); err == nil && front <= i-k {
+			d.PopFront()
+		}
+		for back, err := d.PeekBack(); err == nil && nums[back] < n; back, err = d.PeekBack() {
+			d.PopBack()
+		}
+		d.PushBack(i)
+		if i >= k-1 {
+			front, _ := d.PeekFront()
+			out = append(out, nums[front])
+		}
+	}
+	expected := []int{3, 3, 5, 5, 6, 7}
+	if !reflect.DeepEqual(out, expected) {
+		t.Errorf("Expected %v, but got %v", expected, out)
+	}
+}
+
+func TestDeque_Iterators(t *testing.T) {
+	d := CollectDeque(slices.Values([]int{2, 3}))
+	d.PushFront(1)
+
+	if items := slices.Collect(d.Values()); !reflect.DeepEqual(items, []int{1, 2, 3}) {
+		t.Errorf("Expected Values to go from front to back, but got %v", items)
+	}
+	backward := make([]int, 0)
+	for i, v := range d.Backward() {
+		if v != i+1 {
+			t.Errorf("Expected item %d at position %d, but got %d", i+1, i, v)
+		}
+		backward = append(backward, v)
+	}
+	if !reflect.DeepEqual(backward, []int{3, 2, 1}) {
+		t.Errorf("Expected Backward to go from back to front, but got %v", backward)
+	}
+	for i, v := range d.All() {
+		if v != i+1 {
+			t.Errorf("Expected item %d at position %d, but got %d", i+1, i, v)
+		}
+	}
+}
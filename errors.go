@@ -0,0 +1,40 @@
+package utils
+
+import "errors"
+
+/*
+Sentinel errors shared by every collection
+The collections wrap them in an OpError, so compare with errors.Is rather than ==
+*/
+var (
+	// the collection has no item to remove or look at
+	ErrEmpty = errors.New("Collection is empty")
+	// the collection has no room for another item
+	ErrFull = errors.New("Collection is full")
+	// the collection has been closed and accepts no more items
+	ErrClosed = errors.New("Collection is closed")
+	// the index is negative or not smaller than the size
+	ErrOutOfRange = errors.New("Index out of range")
+	// the item clashes with one the collection already holds, such as a BiMap value that is mapped to another key
+	ErrConflict = errors.New("Item conflicts with an existing item")
+)
+
+/*
+An error that records which operation failed and why
+Op names the type and method, for example "FifoQueue.Dequeue", and Err is usually one of the sentinel errors
+*/
+type OpError struct {
+	Op  string
+	Err error
+}
+
+func (e *OpError) Error() string {
+	return e.Op + ": " + e.Err.Error()
+}
+
+/*
+Returns the underlying error, which makes errors.Is and errors.As see through the OpError
+*/
+func (e *OpError) Unwrap() error {
+	return e.Err
+}
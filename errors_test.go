@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestErrors_EmptyCollections(t *testing.T) {
+	deque := NewDeque[int]()
+	ipq := NewIndexedPriorityQueue[string, int](cmp)
+	minMax := NewMinMaxHeap(cmp)
+	treeMap := NewTreeMap[int, int](cmp)
+
+	calls := map[string]func() error{
+		"SliceStack.Pop":                  func() error { _, err := NewSliceStack[int]().Pop(); return err },
+		"SliceStack.Peek":                 func() error { _, err := NewSliceStack[int]().Peek(); return err },
+		"ConcurrentStack.Pop":             func() error { _, err := NewConcurrentStack[int]().Pop(); return err },
+		"ConcurrentStack.Peek":            func() error { _, err := NewConcurrentStack[int]().Peek(); return err },
+		"FifoQueue.Dequeue":               func() error { _, err := NewFifoQueue[int]().Dequeue(); return err },
+		"FifoQueue.Peek":                  func() error { _, err := NewFifoQueue[int]().Peek(); return err },
+		"PriorityQueue.Dequeue":           func() error { _, err := NewPriorityQueue(cmp).Dequeue(); return err },
+		"PriorityQueue.Peek":              func() error { _, err := NewPriorityQueue(cmp).Peek(); return err },
+		"IndexedPriorityQueue.Dequeue":    func() error { _, _, err := ipq.Dequeue(); return err },
+		"IndexedPriorityQueue.Peek":       func() error { _, err := ipq.Peek(); return err },
+		"IndexedPriorityQueue.PeekKey":    func() error { _, err := ipq.PeekKey(); return err },
+		"BlockingQueue.Dequeue":           func() error { _, err := NewBlockingQueue[int]().Dequeue(); return err },
+		"BlockingQueue.Peek":              func() error { _, err := NewBlockingQueue[int]().Peek(); return err },
+		"BlockingQueue.Poll":              func() error { _, err := NewBlockingQueue[int]().Poll(time.Millisecond); return err },
+		"Deque.PopFront":                  func() error { _, err := deque.PopFront(); return err },
+		"Deque.PopBack":                   func() error { _, err := deque.PopBack(); return err },
+		"Deque.PeekFront":                 func() error { _, err := deque.PeekFront(); return err },
+		"Deque.PeekBack":                  func() error { _, err := deque.PeekBack(); return err },
+		"Deque.Pop":                       func() error { _, err := deque.Pop(); return err },
+		"Deque.Dequeue":                   func() error { _, err := deque.Dequeue(); return err },
+		"Deque.Peek":                      func() error { _, err := deque.Peek(); return err },
+		"DequeStack.Pop":                  func() error { _, err := deque.AsStack().Pop(); return err },
+		"DequeStack.Peek":                 func() error { _, err := deque.AsStack().Peek(); return err },
+		"MinMaxHeap.PopMin":               func() error { _, err := minMax.PopMin(); return err },
+		"MinMaxHeap.PopMax":               func() error { _, err := minMax.PopMax(); return err },
+		"MinMaxHeap.PeekMin":              func() error { _, err := minMax.PeekMin(); return err },
+		"MinMaxHeap.PeekMax":              func() error { _, err := minMax.PeekMax(); return err },
+		"MinMaxHeap.Dequeue":              func() error { _, err := minMax.Dequeue(); return err },
+		"MinMaxHeap.Peek":                 func() error { _, err := minMax.Peek(); return err },
+		"TreeMap.First":                   func() error { _, err := treeMap.First(); return err },
+		"TreeMap.Last":                    func() error { _, err := treeMap.Last(); return err },
+		"StablePriorityQueue.Dequeue":     func() error { _, err := NewStablePriorityQueue(cmp).Dequeue(); return err },
+		"StablePriorityQueue.Peek":        func() error { _, err := NewStablePriorityQueue(cmp).Peek(); return err },
+		"BoundedPriorityQueue.Dequeue":    func() error { _, err := NewBoundedPriorityQueue(1, cmp).Dequeue(); return err },
+		"BoundedPriorityQueue.Peek":       func() error { _, err := NewBoundedPriorityQueue(1, cmp).Peek(); return err },
+		"BoundedPriorityQueue.PeekWorst":  func() error { _, err := NewBoundedPriorityQueue(1, cmp).PeekWorst(); return err },
+		"PriorityQueue.Dequeue (pairing)": func() error { _, err := NewPriorityQueueWithStrategy(cmp, PairingHeap()).Dequeue(); return err },
+	}
+	for name, call := range calls {
+		err := call()
+		if !errors.Is(err, ErrEmpty) {
+			t.Errorf("%s: expected an error wrapping ErrEmpty, but got %v", name, err)
+		}
+		// A note in parentheses tells apart calls to the same operation
+		op, _, _ := strings.Cut(name, " (")
+		var opErr *OpError
+		if !errors.As(err, &opErr) || opErr.Op != op {
+			t.Errorf("%s: expected an OpError naming the operation %s, but got %v", name, op, err)
+		}
+	}
+}
+
+func TestErrors_OpErrorNamesTheOperation(t *testing.T) {
+	_, err := NewFifoQueue[int]().Dequeue()
+	var opErr *OpError
+	if !errors.As(err, &opErr) || opErr.Op != "FifoQueue.Dequeue" {
+		t.Errorf("Expected the operation FifoQueue.Dequeue, but got %v", err)
+	}
+	if err.Error() != "FifoQueue.Dequeue: Collection is empty" {
+		t.Errorf("Unexpected message: %s", err.Error())
+	}
+	if errors.Is(err, ErrFull) || errors.Is(err, ErrClosed) {
+		t.Errorf("Expected the error to match only ErrEmpty")
+	}
+}
+
+func TestErrors_OutOfRange(t *testing.T) {
+	deque := CollectDeque(slices.Values([]int{1, 2, 3}))
+	view := NewListMultimap[string, int]().Get("a")
+	calls := map[string]func() error{
+		"Deque.At":    func() error { _, err := deque.At(3); return err },
+		"ListView.At": func() error { _, err := view.At(-1); return err },
+	}
+	for name, call := range calls {
+		err := call()
+		var opErr *OpError
+		if !errors.Is(err, ErrOutOfRange) || !errors.As(err, &opErr) || opErr.Op != name {
+			t.Errorf("%s: expected an OpError wrapping ErrOutOfRange, but got %v", name, err)
+		}
+	}
+	if _, err := deque.At(3); err.Error() != "Deque.At: Index out of range: 3 for size 3" {
+		t.Errorf("Unexpected message: %s", err.Error())
+	}
+}
+
+func TestErrors_Conflict(t *testing.T) {
+	m := NewBiMap[int, string]()
+	m.Put(1, "One")
+	err := m.TryPut(2, "One")
+	var opErr *OpError
+	if !errors.Is(err, ErrConflict) || !errors.As(err, &opErr) || opErr.Op != "BiMap.TryPut" {
+		t.Errorf("Expected an OpError wrapping ErrConflict, but got %v", err)
+	}
+}
+
+func TestErrors_BlockingQueue(t *testing.T) {
+	q := NewBoundedBlockingQueue[int](1)
+	q.Put(context.Background(), 1)
+	if err := q.Offer(2, time.Millisecond); !errors.Is(err, ErrFull) {
+		t.Errorf("Expected Offer on a full queue to wrap ErrFull, but got %v", err)
+	}
+
+	q.Close()
+	if err := q.Put(context.Background(), 2); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected Put on a closed queue to wrap ErrClosed, but got %v", err)
+	}
+	if err := q.Offer(2, time.Millisecond); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected Offer on a closed queue to wrap ErrClosed, but got %v", err)
+	}
+	q.Take(context.Background())
+	if _, err := q.Take(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected Take on a closed and drained queue to wrap ErrClosed, but got %v", err)
+	}
+	if _, err := q.Poll(time.Millisecond); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected Poll on a closed and drained queue to wrap ErrClosed, but got %v", err)
+	}
+}
@@ -0,0 +1,281 @@
+package utils
+
+/*
+A Fibonacci heap backend for PriorityQueue and IndexedPriorityQueue, selected with the FibonacciHeap strategy
+The roots form a circular doubly linked list with a pointer to the top.
+Insertion adds a root in O(1), and removing the top consolidates the roots so no two have the same degree.
+Decreasing an item cuts its node loose and makes it a root, which is what gives the heap its amortized O(1) decrease-key
+*/
+type fibonacciHeap[T interface{}] struct {
+	comparator func(T, T) int
+	top        *fibonacciNode[T]
+	n          int
+	// scratch space of consolidate, kept between calls to avoid allocating on every pop
+	roots    []*fibonacciNode[T]
+	byDegree []*fibonacciNode[T]
+}
+
+type fibonacciNode[T interface{}] struct {
+	item   T
+	parent *fibonacciNode[T]
+	child  *fibonacciNode[T]
+	left   *fibonacciNode[T]
+	right  *fibonacciNode[T]
+	degree int
+	// true if the node has lost a child since it became the child of its parent
+	marked bool
+}
+
+func newFibonacciHeap[T interface{}](comp func(T, T) int) *fibonacciHeap[T] {
+	return &fibonacciHeap[T]{comparator: comp}
+}
+
+// O(1)
+func (h *fibonacciHeap[T]) push(item T) {
+	h.insert(item)
+}
+
+// O(1), returns the node of the item so it can be decreased or removed later
+func (h *fibonacciHeap[T]) insert(item T) *fibonacciNode[T] {
+	node := &fibonacciNode[T]{item: item}
+	node.left = node
+	node.right = node
+	h.addRoot(node)
+	h.n++
+	return node
+}
+
+// amortized O(log n)
+func (h *fibonacciHeap[T]) pop() T {
+	top := h.top
+	if top.child != nil {
+		child := top.child
+		for {
+			child.parent = nil
+			child = child.right
+			if child == top.child {
+				break
+			}
+		}
+		spliceLists(top, top.child)
+		top.child = nil
+	}
+	if top.right == top {
+		h.top = nil
+	} else {
+		top.left.right = top.right
+		top.right.left = top.left
+		h.top = top.right
+		h.consolidate()
+	}
+	h.n--
+	return top.item
+}
+
+// O(1)
+func (h *fibonacciHeap[T]) peek() T {
+	return h.top.item
+}
+
+func (h *fibonacciHeap[T]) size() int {
+	return h.n
+}
+
+func (h *fibonacciHeap[T]) clear() {
+	h.top = nil
+	h.n = 0
+	h.roots = nil
+	h.byDegree = nil
+}
+
+// amortized O(1), assumes the node is in the heap and the item does not come after its current item
+func (h *fibonacciHeap[T]) decrease(node *fibonacciNode[T], item T) {
+	node.item = item
+	if parent := node.parent; parent != nil && h.comparator(item, parent.item) < 0 {
+		h.cut(node)
+		h.cascadingCut(parent)
+	}
+	if h.comparator(item, h.top.item) < 0 {
+		h.top = node
+	}
+}
+
+// amortized O(log n), assumes the node is in the heap
+func (h *fibonacciHeap[T]) remove(node *fibonacciNode[T]) T {
+	if parent := node.parent; parent != nil {
+		h.cut(node)
+		h.cascadingCut(parent)
+	}
+	// pop removes whichever root top points to, and finds the real top again while consolidating
+	h.top = node
+	return h.pop()
+}
+
+// O(n)
+func (h *fibonacciHeap[T]) items() []T {
+	out := make([]T, 0, h.n)
+	lists := make([]*fibonacciNode[T], 0)
+	if h.top != nil {
+		lists = append(lists, h.top)
+	}
+	for len(lists) > 0 {
+		first := lists[len(lists)-1]
+		lists = lists[:len(lists)-1]
+		node := first
+		for {
+			out = append(out, node.item)
+			if node.child != nil {
+				lists = append(lists, node.child)
+			}
+			node = node.right
+			if node == first {
+				break
+			}
+		}
+	}
+	return out
+}
+
+// O(n), the copy keeps every item as a root until it is first popped
+func (h *fibonacciHeap[T]) clone() nodeHeap[T] {
+	out := newFibonacciHeap(h.comparator)
+	for _, item := range h.items() {
+		out.push(item)
+	}
+	return out
+}
+
+// O(1)
+func (h *fibonacciHeap[T]) meld(other nodeHeap[T]) bool {
+	o, ok := other.(*fibonacciHeap[T])
+	if !ok {
+		return false
+	}
+	if o.top != nil {
+		top := o.top
+		if h.top == nil {
+			h.top = top
+		} else {
+			spliceLists(h.top, top)
+			if h.comparator(top.item, h.top.item) < 0 {
+				h.top = top
+			}
+		}
+	}
+	h.n += o.n
+	o.clear()
+	return true
+}
+
+// PRIVATE HELPER FUNCTIONS BELOW
+
+// adds a node that forms a list of its own to the roots
+func (h *fibonacciHeap[T]) addRoot(node *fibonacciNode[T]) {
+	if h.top == nil {
+		h.top = node
+		return
+	}
+	spliceLists(h.top, node)
+	if h.comparator(node.item, h.top.item) < 0 {
+		h.top = node
+	}
+}
+
+// links roots of equal degree until every root has a unique degree, then finds the new top
+func (h *fibonacciHeap[T]) consolidate() {
+	roots := h.roots[:0]
+	node := h.top
+	for {
+		roots = append(roots, node)
+		node = node.right
+		if node == h.top {
+			break
+		}
+	}
+
+	byDegree := h.byDegree[:0]
+	for _, x := range roots {
+		x.left = x
+		x.right = x
+		d := x.degree
+		for d < len(byDegree) && byDegree[d] != nil {
+			y := byDegree[d]
+			if h.comparator(y.item, x.item) < 0 {
+				x, y = y, x
+			}
+			h.linkChild(x, y)
+			byDegree[d] = nil
+			d++
+		}
+		for len(byDegree) <= d {
+			byDegree = append(byDegree, nil)
+		}
+		byDegree[d] = x
+	}
+
+	h.top = nil
+	for i, x := range byDegree {
+		if x != nil {
+			h.addRoot(x)
+			byDegree[i] = nil
+		}
+	}
+	clear(roots)
+	h.roots = roots
+	h.byDegree = byDegree
+}
+
+// makes the single node child a child of parent
+func (h *fibonacciHeap[T]) linkChild(parent, child *fibonacciNode[T]) {
+	child.parent = parent
+	child.marked = false
+	if parent.child == nil {
+		parent.child = child
+	} else {
+		spliceLists(parent.child, child)
+	}
+	parent.degree++
+}
+
+// moves the node from the children of its parent to the roots, without updating top
+func (h *fibonacciHeap[T]) cut(node *fibonacciNode[T]) {
+	parent := node.parent
+	if node.right == node {
+		parent.child = nil
+	} else {
+		node.left.right = node.right
+		node.right.left = node.left
+		if parent.child == node {
+			parent.child = node.right
+		}
+	}
+	parent.degree--
+	node.left = node
+	node.right = node
+	node.parent = nil
+	node.marked = false
+	spliceLists(h.top, node)
+}
+
+// cuts the ancestors that have now lost two children, and marks the first one that has lost only one
+func (h *fibonacciHeap[T]) cascadingCut(node *fibonacciNode[T]) {
+	for node.parent != nil {
+		if !node.marked {
+			node.marked = true
+			return
+		}
+		parent := node.parent
+		h.cut(node)
+		node = parent
+	}
+}
+
+// joins two circular lists into one
+func spliceLists[T interface{}](a, b *fibonacciNode[T]) {
+	aRight := a.right
+	bLeft := b.left
+	a.right = b
+	b.left = a
+	bLeft.right = aRight
+	aRight.left = bLeft
+}
@@ -0,0 +1,320 @@
+package utils
+
+/*
+A priority queue where every item is stored under a unique key
+The key can be used to change the priority of an item or remove it in O(log n),
+which is what Dijkstra and A* need for decrease-key
+*/
+type IndexedPriorityQueue[K comparable, T interface{}] struct {
+	comparator func(T, T) int
+	// the heap of the array based strategies, where every node has arity children
+	contents  []indexedItem[K, T]
+	positions map[K]int
+	arity     int
+	// the heap of the Fibonacci strategy and the node of every key, nil for the array based strategies
+	fibonacci *fibonacciHeap[indexedItem[K, T]]
+	handles   map[K]*fibonacciNode[indexedItem[K, T]]
+}
+
+type indexedItem[K comparable, T interface{}] struct {
+	key  K
+	item T
+}
+
+/*
+O(1)
+Instantiates a new empty IndexedPriorityQueue
+The item that is smallest according to the comparator is at the top
+*/
+func NewIndexedPriorityQueue[K comparable, T interface{}](comp func(T, T) int) *IndexedPriorityQueue[K, T] {
+	return NewIndexedPriorityQueueWithStrategy[K](comp, BinaryHeap())
+}
+
+/*
+O(1)
+Instantiates a new empty IndexedPriorityQueue backed by the heap the strategy selects
+With FibonacciHeap, an Update that decreases an item takes amortized O(1) instead of O(log n),
+which suits graph searches that decrease many keys before they dequeue them
+The pairing heap has no decrease-key here, so PairingHeap gives the same queue as BinaryHeap
+*/
+func NewIndexedPriorityQueueWithStrategy[K comparable, T interface{}](comp func(T, T) int, strategy HeapStrategy) *IndexedPriorityQueue[K, T] {
+	q := &IndexedPriorityQueue[K, T]{
+		comparator: comp,
+		contents:   make([]indexedItem[K, T], 0),
+		positions:  make(map[K]int),
+		arity:      max(strategy.arity, 2)}
+	if strategy.kind == fibonacciHeapKind {
+		q.fibonacci = newFibonacciHeap(func(a, b indexedItem[K, T]) int {
+			return comp(a.item, b.item)
+		})
+		q.handles = make(map[K]*fibonacciNode[indexedItem[K, T]])
+	}
+	return q
+}
+
+/*
+O(log n)
+Assumes: the queue has been instantiated
+Inserts the item under the supplied key
+If the key is already in the queue its item is replaced, as with Update
+*/
+func (q *IndexedPriorityQueue[K, T]) Enqueue(key K, item T) {
+	if q.Update(key, item) {
+		return
+	}
+	if q.fibonacci != nil {
+		q.handles[key] = q.fibonacci.insert(indexedItem[K, T]{key: key, item: item})
+		return
+	}
+	pos := len(q.contents)
+	q.contents = append(q.contents, indexedItem[K, T]{key: key, item: item})
+	q.positions[key] = pos
+	q.siftUp(pos)
+}
+
+/*
+O(log n), or amortized O(1) with the Fibonacci strategy when the item does not come after the one it replaces
+Assumes: the queue has been instantiated
+Replaces the item stored under the supplied key and restores the heap invariant
+Returns false if the key is not in the queue
+*/
+func (q *IndexedPriorityQueue[K, T]) Update(key K, item T) bool {
+	if q.fibonacci != nil {
+		return q.updateNode(key, item)
+	}
+	pos, exists := q.positions[key]
+	if !exists {
+		return false
+	}
+	q.contents[pos].item = item
+	q.fix(pos)
+	return true
+}
+
+/*
+O(log n)
+Assumes: the queue has been instantiated
+Removes the item stored under the supplied key and returns it
+The boolean is false if the key is not in the queue
+*/
+func (q *IndexedPriorityQueue[K, T]) Remove(key K) (T, bool) {
+	if q.fibonacci != nil {
+		node, exists := q.handles[key]
+		if !exists {
+			var nilVal T
+			return nilVal, false
+		}
+		delete(q.handles, key)
+		return q.fibonacci.remove(node).item, true
+	}
+	pos, exists := q.positions[key]
+	if !exists {
+		var nilVal T
+		return nilVal, false
+	}
+	return q.removeAt(pos).item, true
+}
+
+/*
+O(1)
+Assumes: the queue has been instantiated
+Returns true if there is an item stored under the supplied key
+*/
+func (q IndexedPriorityQueue[K, T]) Contains(key K) bool {
+	if q.fibonacci != nil {
+		_, exists := q.handles[key]
+		return exists
+	}
+	_, exists := q.positions[key]
+	return exists
+}
+
+/*
+O(1)
+Assumes: the queue has been instantiated
+Returns the item stored under the supplied key
+The boolean is false if the key is not in the queue
+*/
+func (q IndexedPriorityQueue[K, T]) Get(key K) (T, bool) {
+	if q.fibonacci != nil {
+		node, exists := q.handles[key]
+		if !exists {
+			var nilVal T
+			return nilVal, false
+		}
+		return node.item.item, true
+	}
+	pos, exists := q.positions[key]
+	if !exists {
+		var nilVal T
+		return nilVal, false
+	}
+	return q.contents[pos].item, true
+}
+
+/*
+O(log n)
+Assumes: the queue has been instantiated
+Removes the top item and returns it together with its key
+Returns error if the queue is empty
+*/
+func (q *IndexedPriorityQueue[K, T]) Dequeue() (K, T, error) {
+	if q.IsEmpty() {
+		var nilKey K
+		var nilVal T
+		return nilKey, nilVal, &OpError{Op: "IndexedPriorityQueue.Dequeue", Err: ErrEmpty}
+	}
+	if q.fibonacci != nil {
+		top := q.fibonacci.pop()
+		delete(q.handles, top.key)
+		return top.key, top.item, nil
+	}
+	top := q.removeAt(0)
+	return top.key, top.item, nil
+}
+
+/*
+O(1)
+Assumes: the queue has been instantiated
+Returns the top item without removing it
+Returns error if the queue is empty
+*/
+func (q IndexedPriorityQueue[K, T]) Peek() (T, error) {
+	if q.IsEmpty() {
+		var nilVal T
+		return nilVal, &OpError{Op: "IndexedPriorityQueue.Peek", Err: ErrEmpty}
+	}
+	return q.top().item, nil
+}
+
+/*
+O(1)
+Assumes: the queue has been instantiated
+Returns the key of the top item without removing it
+Returns error if the queue is empty
+*/
+func (q IndexedPriorityQueue[K, T]) PeekKey() (K, error) {
+	if q.IsEmpty() {
+		var nilKey K
+		return nilKey, &OpError{Op: "IndexedPriorityQueue.PeekKey", Err: ErrEmpty}
+	}
+	return q.top().key, nil
+}
+
+/*
+O(1)
+Assumes: the queue has been instantiated
+Returns true if the queue is empty
+*/
+func (q IndexedPriorityQueue[K, T]) IsEmpty() bool {
+	return q.Size() == 0
+}
+
+/*
+O(1)
+Assumes: the queue has been instantiated
+Returns the number of items in the queue
+*/
+func (q IndexedPriorityQueue[K, T]) Size() int {
+	if q.fibonacci != nil {
+		return q.fibonacci.size()
+	}
+	return len(q.contents)
+}
+
+/*
+O(1)
+Wipes contents of the queue
+*/
+func (q *IndexedPriorityQueue[K, T]) Clear() {
+	q.contents = make([]indexedItem[K, T], 0)
+	q.positions = make(map[K]int)
+	if q.fibonacci != nil {
+		q.fibonacci.clear()
+		q.handles = make(map[K]*fibonacciNode[indexedItem[K, T]])
+	}
+}
+
+// PRIVATE HELPER FUNCTIONS BELOW
+
+// assumes the queue is not empty
+func (q IndexedPriorityQueue[K, T]) top() indexedItem[K, T] {
+	if q.fibonacci != nil {
+		return q.fibonacci.peek()
+	}
+	return q.contents[0]
+}
+
+// Update for the Fibonacci strategy: an item that comes later is removed and inserted again
+func (q *IndexedPriorityQueue[K, T]) updateNode(key K, item T) bool {
+	node, exists := q.handles[key]
+	if !exists {
+		return false
+	}
+	replacement := indexedItem[K, T]{key: key, item: item}
+	if q.comparator(item, node.item.item) <= 0 {
+		q.fibonacci.decrease(node, replacement)
+		return true
+	}
+	q.fibonacci.remove(node)
+	q.handles[key] = q.fibonacci.insert(replacement)
+	return true
+}
+
+func (q *IndexedPriorityQueue[K, T]) removeAt(pos int) indexedItem[K, T] {
+	removed := q.contents[pos]
+	last := len(q.contents) - 1
+	q.swap(pos, last)
+	q.contents = q.contents[:last]
+	delete(q.positions, removed.key)
+	if pos < last {
+		q.fix(pos)
+	}
+	return removed
+}
+
+// moves the item at pos up or down until the heap invariant holds
+func (q *IndexedPriorityQueue[K, T]) fix(pos int) {
+	if !q.siftUp(pos) {
+		q.siftDown(pos)
+	}
+}
+
+// returns true if the item moved
+func (q *IndexedPriorityQueue[K, T]) siftUp(pos int) bool {
+	moved := false
+	for pos > 0 {
+		parent := (pos - 1) / q.arity
+		if q.comparator(q.contents[parent].item, q.contents[pos].item) <= 0 {
+			break
+		}
+		q.swap(parent, pos)
+		pos = parent
+		moved = true
+	}
+	return moved
+}
+
+func (q *IndexedPriorityQueue[K, T]) siftDown(pos int) {
+	n := len(q.contents)
+	for {
+		smallest := pos
+		first := q.arity*pos + 1
+		for child := first; child < min(first+q.arity, n); child++ {
+			if q.comparator(q.contents[child].item, q.contents[smallest].item) < 0 {
+				smallest = child
+			}
+		}
+		if smallest == pos {
+			return
+		}
+		q.swap(pos, smallest)
+		pos = smallest
+	}
+}
+
+func (q *IndexedPriorityQueue[K, T]) swap(i int, j int) {
+	q.contents[i], q.contents[j] = q.contents[j], q.contents[i]
+	q.positions[q.contents[i].key] = i
+	q.positions[q.contents[j].key] = j
+}
@@ -0,0 +1,309 @@
+package utils
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestIndexedPriorityQueue_EnqueueAndDequeue(t *testing.T) {
+	pq := NewIndexedPriorityQueue[string, int](cmp)
+	pq.Enqueue("c", 30)
+	pq.Enqueue("a", 10)
+	pq.Enqueue("b", 20)
+
+	key, err := pq.PeekKey()
+	if err != nil || key != "a" {
+		t.Errorf("Expected top key 'a', but got '%s' (err: %v)", key, err)
+	}
+
+	expectedKeys := []string{"a", "b", "c"}
+	expectedItems := []int{10, 20, 30}
+	for i := range expectedKeys {
+		key, item, err := pq.Dequeue()
+		if err != nil || key != expectedKeys[i] || item != expectedItems[i] {
+			t.Errorf("Expected (%s, %d), but got (%s, %d) (err: %v)", expectedKeys[i], expectedItems[i], key, item, err)
+		}
+	}
+	if _, _, err := pq.Dequeue(); err == nil {
+		t.Errorf("Dequeue should return an error when the queue is empty")
+	}
+	if _, err := pq.PeekKey(); err == nil {
+		t.Errorf("PeekKey should return an error when the queue is empty")
+	}
+}
+
+func TestIndexedPriorityQueue_Update(t *testing.T) {
+	pq := NewIndexedPriorityQueue[string, int](cmp)
+	pq.Enqueue("a", 10)
+	pq.Enqueue("b", 20)
+	pq.Enqueue("c", 30)
+
+	// Decrease key moves the item to the top
+	if !pq.Update("c", 5) {
+		t.Errorf("Expected Update of an existing key to succeed")
+	}
+	if key, _ := pq.PeekKey(); key != "c" {
+		t.Errorf("Expected top key 'c' after decreasing it, but got '%s'", key)
+	}
+
+	// Increase key moves the item down
+	pq.Enqueue("c", 25)
+	if key, _ := pq.PeekKey(); key != "a" {
+		t.Errorf("Expected top key 'a' after increasing 'c', but got '%s'", key)
+	}
+	if pq.Size() != 3 {
+		t.Errorf("Expected Enqueue of an existing key to keep the size 3, but got %d", pq.Size())
+	}
+
+	if pq.Update("d", 1) {
+		t.Errorf("Expected Update of a missing key to fail")
+	}
+	if item, ok := pq.Get("c"); !ok || item != 25 {
+		t.Errorf("Expected item 25 for key 'c', but got %d (%v)", item, ok)
+	}
+}
+
+func TestIndexedPriorityQueue_Remove(t *testing.T) {
+	pq := NewIndexedPriorityQueue[int, int](cmp)
+	for i := 0; i < 10; i++ {
+		pq.Enqueue(i, i)
+	}
+
+	if item, ok := pq.Remove(4); !ok || item != 4 {
+		t.Errorf("Expected to remove item 4, but got %d (%v)", item, ok)
+	}
+	if _, ok := pq.Remove(4); ok {
+		t.Errorf("Expected Remove of a missing key to fail")
+	}
+	if pq.Contains(4) || !pq.Contains(5) {
+		t.Errorf("Contains returned the wrong result")
+	}
+
+	out := make([]int, 0)
+	for !pq.IsEmpty() {
+		_, item, _ := pq.Dequeue()
+		out = append(out, item)
+	}
+	expected := []int{0, 1, 2, 3, 5, 6, 7, 8, 9}
+	if !reflect.DeepEqual(out, expected) {
+		t.Errorf("Expected %v, but got %v", expected, out)
+	}
+}
+
+func TestIndexedPriorityQueue_RandomOperations(t *testing.T) {
+	for name, strategy := range heapStrategies {
+		t.Run(name, func(t *testing.T) {
+			pq := NewIndexedPriorityQueueWithStrategy[int](cmp, strategy)
+			reference := make(map[int]int)
+			for i := 0; i < 5000; i++ {
+				key := rand.Intn(200)
+				switch rand.Intn(4) {
+				case 0:
+					pq.Remove(key)
+					delete(reference, key)
+				case 1:
+					// Mostly decreases, which take the Fibonacci heap's decrease-key path
+					if old, exists := reference[key]; exists {
+						item := old - rand.Intn(50)
+						pq.Update(key, item)
+						reference[key] = item
+					}
+				case 2:
+					if key, _, err := pq.Dequeue(); err == nil {
+						delete(reference, key)
+					}
+				default:
+					item := rand.Intn(1000)
+					pq.Enqueue(key, item)
+					reference[key] = item
+				}
+				if err := indexedPqInvariant(pq); err != nil {
+					t.Fatalf("%s after %d operations", err.Error(), i)
+				}
+			}
+
+			if pq.Size() != len(reference) {
+				t.Errorf("Expected size %d, but got %d", len(reference), pq.Size())
+			}
+			previous := math.MinInt
+			for !pq.IsEmpty() {
+				key, item, _ := pq.Dequeue()
+				if item < previous || reference[key] != item {
+					t.Errorf("Dequeued (%d, %d) out of order or with a stale item", key, item)
+				}
+				previous = item
+			}
+		})
+	}
+}
+
+func TestIndexedPriorityQueue_FibonacciDecreaseKey(t *testing.T) {
+	pq := NewIndexedPriorityQueueWithStrategy[int](cmp, FibonacciHeap())
+	for i := 0; i < 100; i++ {
+		pq.Enqueue(i, 1000+i)
+	}
+	// A dequeue consolidates the roots into trees, so the decreases below cut nodes out of them
+	if key, _, _ := pq.Dequeue(); key != 0 {
+		t.Errorf("Expected key 0 first, but got %d", key)
+	}
+	for i := 99; i > 50; i-- {
+		if !pq.Update(i, i) {
+			t.Errorf("Expected Update of key %d to succeed", i)
+		}
+		if err := indexedPqInvariant(pq); err != nil {
+			t.Fatalf("%s after decreasing key %d", err.Error(), i)
+		}
+	}
+	if key, _ := pq.PeekKey(); key != 51 {
+		t.Errorf("Expected top key 51 after the decreases, but got %d", key)
+	}
+	// Increasing a key moves it back behind the others
+	pq.Update(51, 5000)
+	if key, _ := pq.PeekKey(); key != 52 {
+		t.Errorf("Expected top key 52 after increasing 51, but got %d", key)
+	}
+	if item, ok := pq.Remove(60); !ok || item != 60 {
+		t.Errorf("Expected to remove item 60, but got %d (%v)", item, ok)
+	}
+	if err := indexedPqInvariant(pq); err != nil {
+		t.Fatal(err)
+	}
+	if pq.Size() != 98 {
+		t.Errorf("Expected 98 items, but got %d", pq.Size())
+	}
+	pq.Clear()
+	if !pq.IsEmpty() || pq.Contains(52) {
+		t.Errorf("Expected Clear to empty the queue")
+	}
+}
+
+func TestIndexedPriorityQueue_Dijkstra(t *testing.T) {
+	// edges[from][to] = weight
+	edges := map[string]map[string]int{
+		"a": {"b": 7, "c": 9, "f": 14},
+		"b": {"a": 7, "c": 10, "d": 15},
+		"c": {"a": 9, "b": 10, "d": 11, "f": 2},
+		"d": {"b": 15, "c": 11, "e": 6},
+		"e": {"d": 6, "f": 9},
+		"f": {"a": 14, "c": 2, "e": 9},
+	}
+	expected := map[string]int{"a": 0, "b": 7, "c": 9, "d": 20, "e": 20, "f": 11}
+	for name, strategy := range heapStrategies {
+		dist := map[string]int{"a": 0}
+		pq := NewIndexedPriorityQueueWithStrategy[string](cmp, strategy)
+		pq.Enqueue("a", 0)
+		for !pq.IsEmpty() {
+			node, d, _ := pq.Dequeue()
+			for next, w := range edges[node] {
+				if old, seen := dist[next]; !seen || d+w < old {
+					dist[next] = d + w
+					pq.Enqueue(next, d+w)
+				}
+			}
+		}
+
+		if !reflect.DeepEqual(dist, expected) {
+			t.Errorf("%s: expected distances %v, but got %v", name, expected, dist)
+		}
+	}
+}
+
+func BenchmarkIndexedPriorityQueue_DecreaseKey(b *testing.B) {
+	const n = 100_000
+	for _, name := range []string{"Binary", "Fibonacci"} {
+		strategy := heapStrategies[name]
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				pq := NewIndexedPriorityQueueWithStrategy[int](cmp, strategy)
+				for key := 0; key < n; key++ {
+					pq.Enqueue(key, 2*n+key)
+				}
+				pq.Dequeue()
+				// Decrease every key a few times before draining, as a dense graph search would
+				for round := 2; round > 0; round-- {
+					for key := 1; key < n; key++ {
+						pq.Update(key, round*n-key)
+					}
+				}
+				for !pq.IsEmpty() {
+					pq.Dequeue()
+				}
+			}
+		})
+	}
+}
+
+func indexedPqInvariant[K comparable, T interface{}](pq *IndexedPriorityQueue[K, T]) error {
+	if pq.fibonacci != nil {
+		return fibonacciInvariant(pq)
+	}
+	if len(pq.positions) != len(pq.contents) {
+		return errors.New("Position index out of sync")
+	}
+	for i, entry := range pq.contents {
+		if pq.positions[entry.key] != i {
+			return errors.New("Position index points to the wrong slot")
+		}
+		if i > 0 && pq.comparator(pq.contents[(i-1)/pq.arity].item, entry.item) > 0 {
+			return errors.New("Heap invariant broken")
+		}
+	}
+	return nil
+}
+
+// checks the links, degrees and order of every node, and that the handles point at the nodes of their keys
+func fibonacciInvariant[K comparable, T interface{}](pq *IndexedPriorityQueue[K, T]) error {
+	h := pq.fibonacci
+	if h.top == nil {
+		if h.n != 0 || len(pq.handles) != 0 {
+			return errors.New("Empty heap with a non-zero size")
+		}
+		return nil
+	}
+	count := 0
+	var visit func(first *fibonacciNode[indexedItem[K, T]], parent *fibonacciNode[indexedItem[K, T]]) (int, error)
+	visit = func(first *fibonacciNode[indexedItem[K, T]], parent *fibonacciNode[indexedItem[K, T]]) (int, error) {
+		siblings := 0
+		node := first
+		for {
+			siblings++
+			count++
+			if node.parent != parent || node.right.left != node {
+				return 0, errors.New("Broken links")
+			}
+			if parent != nil && pq.comparator(parent.item.item, node.item.item) > 0 {
+				return 0, errors.New("Heap invariant broken")
+			}
+			if parent == nil && pq.comparator(h.top.item.item, node.item.item) > 0 {
+				return 0, errors.New("Top is not the smallest root")
+			}
+			if pq.handles[node.item.key] != node {
+				return 0, errors.New("Handle points to the wrong node")
+			}
+			degree := 0
+			if node.child != nil {
+				var err error
+				if degree, err = visit(node.child, node); err != nil {
+					return 0, err
+				}
+			}
+			if degree != node.degree {
+				return 0, errors.New("Degree out of sync")
+			}
+			node = node.right
+			if node == first {
+				return siblings, nil
+			}
+		}
+	}
+	if _, err := visit(h.top, nil); err != nil {
+		return err
+	}
+	if count != h.n || count != len(pq.handles) {
+		return errors.New("Size out of sync")
+	}
+	return nil
+}
@@ -0,0 +1,437 @@
+package utils
+
+import "iter"
+
+/*
+An implementation of the Map interface that remembers the order of its entries
+Keys, Values and ForEach all walk the entries from front to back.
+In insertion order (the default) a new key is placed at the back and overwriting
+an existing key keeps its position.
+In access order every Put, Merge and Get of an existing key also moves it to the back,
+so the front always holds the least recently used entry
+*/
+type LinkedHashMap[K comparable, V interface{}] struct {
+	items       map[K]*linkedEntry[K, V]
+	head        *linkedEntry[K, V]
+	tail        *linkedEntry[K, V]
+	accessOrder bool
+}
+
+type linkedEntry[K comparable, V interface{}] struct {
+	key   K
+	value V
+	prev  *linkedEntry[K, V]
+	next  *linkedEntry[K, V]
+}
+
+/*
+O(1)
+Instantiates a new empty LinkedHashMap in insertion order
+*/
+func NewLinkedHashMap[K comparable, V interface{}]() *LinkedHashMap[K, V] {
+	return &LinkedHashMap[K, V]{items: make(map[K]*linkedEntry[K, V])}
+}
+
+/*
+O(1)
+Instantiates a new empty LinkedHashMap in access order
+*/
+func NewAccessOrderedLinkedHashMap[K comparable, V interface{}]() *LinkedHashMap[K, V] {
+	m := NewLinkedHashMap[K, V]()
+	m.accessOrder = true
+	return m
+}
+
+/*
+O(1)
+Assumes: LinkedHashMap m has been instantiated
+Ensures: the key is mapped to the value
+A new key is placed at the back
+*/
+func (m *LinkedHashMap[K, V]) Put(key K, value V) {
+	if entry, exists := m.items[key]; exists {
+		entry.value = value
+		m.touch(entry)
+		return
+	}
+	entry := &linkedEntry[K, V]{key: key, value: value}
+	m.items[key] = entry
+	m.linkBack(entry)
+}
+
+/*
+O(1)
+Assumes: LinkedHashMap m has been instantiated
+Returns the value that is associated with the supplied key
+If there is no mapping, the function will return the nil value of the value type
+In access order the entry is moved to the back
+*/
+func (m *LinkedHashMap[K, V]) Get(key K) V {
+	var nilVal V
+	entry, exists := m.items[key]
+	if !exists {
+		return nilVal
+	}
+	m.touch(entry)
+	return entry.value
+}
+
+/*
+O(1)
+Assumes: LinkedHashMap m has been instantiated
+Returns the value that is associated with the supplied key and true,
+or the nil value of the value type and false if there is no mapping
+In access order the entry is moved to the back
+*/
+func (m *LinkedHashMap[K, V]) GetOk(key K) (V, bool) {
+	entry, exists := m.items[key]
+	if !exists {
+		var nilVal V
+		return nilVal, false
+	}
+	m.touch(entry)
+	return entry.value, true
+}
+
+/*
+O(1)
+Assumes: LinkedHashMap m has been instantiated
+Returns the value that is associated with the supplied key, or defaultValue if there is no mapping
+In access order the entry is moved to the back
+*/
+func (m *LinkedHashMap[K, V]) GetOrDefault(key K, defaultValue V) V {
+	if value, exists := m.GetOk(key); exists {
+		return value
+	}
+	return defaultValue
+}
+
+/*
+O(1)
+Assumes: LinkedHashMap m has been instantiated
+Maps the key to the value only if there is no mapping with the supplied key
+Returns the value the key is mapped to afterwards and true if it was already mapped
+In access order an existing entry is moved to the back
+*/
+func (m *LinkedHashMap[K, V]) PutIfAbsent(key K, value V) (V, bool) {
+	if old, exists := m.GetOk(key); exists {
+		return old, true
+	}
+	m.Put(key, value)
+	return value, false
+}
+
+/*
+O(1)
+Assumes: LinkedHashMap m has been instantiated
+Maps the key to the value only if there already is a mapping with the supplied key
+Returns the replaced value and true, or the nil value of the value type and false if nothing was replaced
+*/
+func (m *LinkedHashMap[K, V]) Replace(key K, value V) (V, bool) {
+	entry, exists := m.items[key]
+	if !exists {
+		var nilVal V
+		return nilVal, false
+	}
+	old := entry.value
+	m.Put(key, value)
+	return old, true
+}
+
+/*
+O(1)
+Assumes: LinkedHashMap m has been instantiated
+Removes the mapping with the supplied key
+Returns the removed value and true, or the nil value of the value type and false if there was no mapping
+*/
+func (m *LinkedHashMap[K, V]) Remove(key K) (V, bool) {
+	entry, exists := m.items[key]
+	if !exists {
+		var nilVal V
+		return nilVal, false
+	}
+	delete(m.items, key)
+	m.unlink(entry)
+	return entry.value, true
+}
+
+/*
+O(n)
+Assumes: LinkedHashMap m has been instantiated
+Returns the keys from front to back
+*/
+func (m LinkedHashMap[K, V]) Keys() []K {
+	keys := make([]K, 0, len(m.items))
+	for e := m.head; e != nil; e = e.next {
+		keys = append(keys, e.key)
+	}
+	return keys
+}
+
+/*
+O(n)
+Assumes: LinkedHashMap m has been instantiated
+Returns the values from front to back
+*/
+func (m LinkedHashMap[K, V]) Values() []V {
+	vals := make([]V, 0, len(m.items))
+	for e := m.head; e != nil; e = e.next {
+		vals = append(vals, e.value)
+	}
+	return vals
+}
+
+/*
+O(1)
+Assumes: LinkedHashMap m has been instantiated
+Returns true if there exist a mapping with the supplied key
+Does not change the order of the entries
+*/
+func (m LinkedHashMap[K, V]) ContainsKey(key K) bool {
+	_, exists := m.items[key]
+	return exists
+}
+
+/*
+O(1)
+Assumes: LinkedHashMap m has been instantiated
+If there exists a mapping with the supplied key, the new value will be mergeOp(newVal, oldVal)
+If not, it works as a regular Put
+*/
+func (m *LinkedHashMap[K, V]) Merge(key K, newVal V, mergeOp func(V, V) V) {
+	if entry, exists := m.items[key]; exists {
+		m.Put(key, mergeOp(newVal, entry.value))
+	} else {
+		m.Put(key, newVal)
+	}
+}
+
+/*
+O(1)
+Assumes: LinkedHashMap m has been instantiated
+Updates the mapping with the supplied key
+remap receives the current value and whether it exists, and returns the new value
+and whether the mapping should exist afterwards, so returning false removes it
+Returns the value after the update and whether the mapping exists
+A kept mapping is placed like a Put would place it
+*/
+func (m *LinkedHashMap[K, V]) Compute(key K, remap func(V, bool) (V, bool)) (V, bool) {
+	var old V
+	entry, exists := m.items[key]
+	if exists {
+		old = entry.value
+	}
+	value, keep := remap(old, exists)
+	if !keep {
+		m.Remove(key)
+		var nilVal V
+		return nilVal, false
+	}
+	m.Put(key, value)
+	return value, true
+}
+
+/*
+O(1)
+Assumes: LinkedHashMap m has been instantiated
+Adds a mapping with the value returned by create if there is no mapping with the supplied key
+Nothing is added if create returns false
+Returns the value after the update and whether the mapping exists
+*/
+func (m *LinkedHashMap[K, V]) ComputeIfAbsent(key K, create func() (V, bool)) (V, bool) {
+	return m.Compute(key, absentRemap(create))
+}
+
+/*
+O(1)
+Assumes: LinkedHashMap m has been instantiated
+Replaces the value of an existing mapping with the value returned by remap
+The mapping is removed if remap returns false, and nothing happens if there is no mapping
+Returns the value after the update and whether the mapping exists
+*/
+func (m *LinkedHashMap[K, V]) ComputeIfPresent(key K, remap func(V) (V, bool)) (V, bool) {
+	return m.Compute(key, presentRemap(remap))
+}
+
+/*
+O(1)
+Assumes: LinkedHashMap m has been instantiated
+Returns the number of mappings
+*/
+func (m LinkedHashMap[K, V]) Size() int {
+	return len(m.items)
+}
+
+/*
+O(1)
+Assumes: LinkedHashMap m has been instantiated
+Moves the mapping with the supplied key to the front
+Returns false if there is no such mapping
+*/
+func (m *LinkedHashMap[K, V]) MoveToFront(key K) bool {
+	entry, exists := m.items[key]
+	if !exists {
+		return false
+	}
+	m.unlink(entry)
+	m.linkFront(entry)
+	return true
+}
+
+/*
+O(1)
+Assumes: LinkedHashMap m has been instantiated
+Moves the mapping with the supplied key to the back
+Returns false if there is no such mapping
+*/
+func (m *LinkedHashMap[K, V]) MoveToBack(key K) bool {
+	entry, exists := m.items[key]
+	if !exists {
+		return false
+	}
+	m.unlink(entry)
+	m.linkBack(entry)
+	return true
+}
+
+/*
+O(n)
+Assumes: LinkedHashMap m has been instantiated
+Calls visit on every mapping from front to back until visit returns false
+The map must not be modified during the walk
+*/
+func (m LinkedHashMap[K, V]) ForEach(visit func(K, V) bool) {
+	for e := m.head; e != nil; e = e.next {
+		if !visit(e.key, e.value) {
+			return
+		}
+	}
+}
+
+/*
+O(n)
+Assumes: LinkedHashMap m has been instantiated
+Calls visit on every mapping from back to front until visit returns false
+The map must not be modified during the walk
+*/
+func (m LinkedHashMap[K, V]) ForEachReverse(visit func(K, V) bool) {
+	for e := m.tail; e != nil; e = e.prev {
+		if !visit(e.key, e.value) {
+			return
+		}
+	}
+}
+
+/*
+O(1)
+Replaces the contents with an empty map
+*/
+func (m *LinkedHashMap[K, V]) Clear() {
+	m.items = make(map[K]*linkedEntry[K, V])
+	m.head = nil
+	m.tail = nil
+}
+
+/*
+O(1)
+Assumes: LinkedHashMap m has been instantiated
+Returns an iterator over the mappings from front to back
+The iteration does not count as access, and the map must not be modified during it
+*/
+func (m LinkedHashMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.ForEach(yield)
+	}
+}
+
+/*
+O(1)
+Assumes: LinkedHashMap m has been instantiated
+Returns an iterator over the mappings from back to front
+*/
+func (m LinkedHashMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.ForEachReverse(yield)
+	}
+}
+
+/*
+O(1)
+Assumes: LinkedHashMap m has been instantiated
+Returns an iterator over the keys from front to back, without allocating a slice like Keys does
+*/
+func (m LinkedHashMap[K, V]) KeysSeq() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.ForEach(func(k K, _ V) bool { return yield(k) })
+	}
+}
+
+/*
+O(1)
+Assumes: LinkedHashMap m has been instantiated
+Returns an iterator over the values from front to back, without allocating a slice like Values does
+*/
+func (m LinkedHashMap[K, V]) ValuesSeq() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		m.ForEach(func(_ K, v V) bool { return yield(v) })
+	}
+}
+
+/*
+O(n)
+Instantiates a new insertion-ordered LinkedHashMap holding the mappings of the iterator
+*/
+func CollectLinkedHashMap[K comparable, V interface{}](seq iter.Seq2[K, V]) *LinkedHashMap[K, V] {
+	m := NewLinkedHashMap[K, V]()
+	for k, v := range seq {
+		m.Put(k, v)
+	}
+	return m
+}
+
+// PRIVATE HELPER FUNCTIONS BELOW
+
+// records an access to an existing entry
+func (m *LinkedHashMap[K, V]) touch(entry *linkedEntry[K, V]) {
+	if m.accessOrder && entry != m.tail {
+		m.unlink(entry)
+		m.linkBack(entry)
+	}
+}
+
+func (m *LinkedHashMap[K, V]) linkBack(entry *linkedEntry[K, V]) {
+	entry.prev = m.tail
+	entry.next = nil
+	if m.tail == nil {
+		m.head = entry
+	} else {
+		m.tail.next = entry
+	}
+	m.tail = entry
+}
+
+func (m *LinkedHashMap[K, V]) linkFront(entry *linkedEntry[K, V]) {
+	entry.prev = nil
+	entry.next = m.head
+	if m.head == nil {
+		m.tail = entry
+	} else {
+		m.head.prev = entry
+	}
+	m.head = entry
+}
+
+func (m *LinkedHashMap[K, V]) unlink(entry *linkedEntry[K, V]) {
+	if entry.prev == nil {
+		m.head = entry.next
+	} else {
+		entry.prev.next = entry.next
+	}
+	if entry.next == nil {
+		m.tail = entry.prev
+	} else {
+		entry.next.prev = entry.prev
+	}
+	entry.prev = nil
+	entry.next = nil
+}
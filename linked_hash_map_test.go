@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestLinkedHashMap_InsertionOrder(t *testing.T) {
+	var m Map[string, int] = NewLinkedHashMap[string, int]()
+	m.Put("c", 3)
+	m.Put("a", 1)
+	m.Put("b", 2)
+	// Overwriting keeps the original position
+	m.Put("c", 30)
+	m.Get("a")
+
+	expectedKeys := []string{"c", "a", "b"}
+	expectedValues := []int{30, 1, 2}
+	if !reflect.DeepEqual(m.Keys(), expectedKeys) {
+		t.Errorf("Expected keys %v, but got %v", expectedKeys, m.Keys())
+	}
+	if !reflect.DeepEqual(m.Values(), expectedValues) {
+		t.Errorf("Expected values %v, but got %v", expectedValues, m.Values())
+	}
+}
+
+func TestLinkedHashMap_AccessOrder(t *testing.T) {
+	m := NewAccessOrderedLinkedHashMap[string, int]()
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 3)
+	m.Get("a")
+	m.Put("b", 20)
+	// ContainsKey and missing keys do not count as accesses
+	m.ContainsKey("c")
+	m.Get("d")
+
+	expected := []string{"c", "a", "b"}
+	if !reflect.DeepEqual(m.Keys(), expected) {
+		t.Errorf("Expected keys %v, but got %v", expected, m.Keys())
+	}
+}
+
+func TestLinkedHashMap_Remove(t *testing.T) {
+	m := NewLinkedHashMap[int, int]()
+	for i := 0; i < 5; i++ {
+		m.Put(i, i)
+	}
+	m.Remove(0)
+	m.Remove(2)
+	m.Remove(4)
+	m.Remove(7)
+
+	expected := []int{1, 3}
+	if !reflect.DeepEqual(m.Keys(), expected) {
+		t.Errorf("Expected keys %v, but got %v", expected, m.Keys())
+	}
+	if m.Size() != 2 {
+		t.Errorf("Expected size 2, but got %d", m.Size())
+	}
+
+	m.Remove(1)
+	m.Remove(3)
+	if m.head != nil || m.tail != nil {
+		t.Errorf("Expected an empty list after removing every key")
+	}
+	m.Put(9, 9)
+	if !reflect.DeepEqual(m.Keys(), []int{9}) {
+		t.Errorf("Expected keys [9], but got %v", m.Keys())
+	}
+}
+
+func TestLinkedHashMap_MoveToFrontAndBack(t *testing.T) {
+	m := NewLinkedHashMap[int, string]()
+	m.Put(1, "One")
+	m.Put(2, "Two")
+	m.Put(3, "Three")
+
+	if !m.MoveToFront(3) || !m.MoveToBack(1) {
+		t.Errorf("Expected moving existing keys to succeed")
+	}
+	if m.MoveToFront(4) || m.MoveToBack(4) {
+		t.Errorf("Expected moving a missing key to fail")
+	}
+
+	expected := []int{3, 2, 1}
+	if !reflect.DeepEqual(m.Keys(), expected) {
+		t.Errorf("Expected keys %v, but got %v", expected, m.Keys())
+	}
+
+	reversed := make([]int, 0)
+	m.ForEachReverse(func(k int, _ string) bool {
+		reversed = append(reversed, k)
+		return true
+	})
+	expected = []int{1, 2, 3}
+	if !reflect.DeepEqual(reversed, expected) {
+		t.Errorf("Expected reverse walk %v, but got %v", expected, reversed)
+	}
+}
+
+func TestLinkedHashMap_Merge(t *testing.T) {
+	m := NewLinkedHashMap[string, int]()
+	sum := func(a, b int) int { return a + b }
+	for _, word := range []string{"to", "be", "or", "not", "to", "be"} {
+		m.Merge(word, 1, sum)
+	}
+
+	visited := make([]string, 0)
+	m.ForEach(func(k string, v int) bool {
+		visited = append(visited, k)
+		return v == 2
+	})
+	expected := []string{"to", "be", "or"}
+	if !reflect.DeepEqual(visited, expected) {
+		t.Errorf("Expected walk %v, but got %v", expected, visited)
+	}
+	if m.Get("to") != 2 || m.Get("not") != 1 {
+		t.Errorf("Merge produced the wrong counts: %v", m.Values())
+	}
+}
+
+func TestLinkedHashMap_Iterators(t *testing.T) {
+	source := NewTreeMap[int, string](cmp)
+	source.Put(2, "Two")
+	source.Put(1, "One")
+	source.Put(3, "Three")
+	m := CollectLinkedHashMap(source.Backward())
+
+	if keys := slices.Collect(m.KeysSeq()); !reflect.DeepEqual(keys, []int{3, 2, 1}) {
+		t.Errorf("Expected KeysSeq in insertion order, but got %v", keys)
+	}
+	if vals := slices.Collect(m.ValuesSeq()); !reflect.DeepEqual(vals, []string{"Three", "Two", "One"}) {
+		t.Errorf("Expected ValuesSeq in insertion order, but got %v", vals)
+	}
+	backward := make([]int, 0)
+	for k := range m.Backward() {
+		backward = append(backward, k)
+	}
+	if !reflect.DeepEqual(backward, []int{1, 2, 3}) {
+		t.Errorf("Expected Backward in reverse insertion order, but got %v", backward)
+	}
+	for k, v := range m.All() {
+		if source.Get(k) != v {
+			t.Errorf("Expected %s for key %d, but got %s", source.Get(k), k, v)
+		}
+	}
+}
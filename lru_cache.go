@@ -0,0 +1,317 @@
+package utils
+
+/*
+A fixed capacity cache that evicts the least recently used entry when it is full
+Implements the Map interface, so it can be used anywhere a Map is accepted.
+Put, Get and Merge count as uses, ContainsKey and Peek do not.
+Keys and Values are returned from the least to the most recently used entry
+*/
+type LRUCache[K comparable, V interface{}] struct {
+	entries  *LinkedHashMap[K, V]
+	capacity int
+	onEvict  func(K, V)
+	stats    CacheStats
+}
+
+/*
+Hit and miss counters of a cache
+Only lookups through Get, GetOk and GetOrDefault are counted
+*/
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+/*
+O(1)
+Instantiates a new empty LRUCache holding at most capacity entries
+A capacity smaller than 1 is treated as 1
+*/
+func NewLRUCache[K comparable, V interface{}](capacity int) *LRUCache[K, V] {
+	return &LRUCache[K, V]{
+		entries:  NewLinkedHashMap[K, V](),
+		capacity: max(capacity, 1)}
+}
+
+/*
+O(1)
+Instantiates a new empty LRUCache holding at most capacity entries
+onEvict is called with every entry that is evicted to make room, but not with entries that are removed
+*/
+func NewLRUCacheWithEvictionCallback[K comparable, V interface{}](capacity int, onEvict func(K, V)) *LRUCache[K, V] {
+	c := NewLRUCache[K, V](capacity)
+	c.onEvict = onEvict
+	return c
+}
+
+/*
+O(1)
+Assumes: LRUCache c has been instantiated
+Ensures: the key is mapped to the value and is the most recently used entry
+Evicts the least recently used entry if the cache is full
+*/
+func (c *LRUCache[K, V]) Put(key K, value V) {
+	c.entries.Put(key, value)
+	c.entries.MoveToBack(key)
+	c.evictOverflow()
+}
+
+/*
+O(1)
+Assumes: LRUCache c has been instantiated
+Returns the value that is associated with the supplied key and marks it as the most recently used
+If there is no mapping, the function will return the nil value of the value type
+*/
+func (c *LRUCache[K, V]) Get(key K) V {
+	value, _ := c.GetOk(key)
+	return value
+}
+
+/*
+O(1)
+Assumes: LRUCache c has been instantiated
+Returns the value that is associated with the supplied key without marking it as used
+The boolean is false if there is no such mapping
+*/
+func (c LRUCache[K, V]) Peek(key K) (V, bool) {
+	entry, exists := c.entries.items[key]
+	if !exists {
+		var nilVal V
+		return nilVal, false
+	}
+	return entry.value, true
+}
+
+/*
+O(1)
+Assumes: LRUCache c has been instantiated
+Returns the value that is associated with the supplied key and true, and marks it as the most recently used
+Returns the nil value of the value type and false if there is no mapping
+Counts as a hit or a miss like Get
+*/
+func (c *LRUCache[K, V]) GetOk(key K) (V, bool) {
+	entry, exists := c.entries.items[key]
+	if !exists {
+		c.stats.Misses++
+		var nilVal V
+		return nilVal, false
+	}
+	c.stats.Hits++
+	c.entries.MoveToBack(key)
+	return entry.value, true
+}
+
+/*
+O(1)
+Assumes: LRUCache c has been instantiated
+Returns the value that is associated with the supplied key, or defaultValue if there is no mapping
+Counts as a hit or a miss like Get
+*/
+func (c *LRUCache[K, V]) GetOrDefault(key K, defaultValue V) V {
+	if value, exists := c.GetOk(key); exists {
+		return value
+	}
+	return defaultValue
+}
+
+/*
+O(1)
+Assumes: LRUCache c has been instantiated
+Maps the key to the value only if there is no mapping with the supplied key
+Returns the value the key is mapped to afterwards and true if it was already mapped
+Either way the entry becomes the most recently used
+*/
+func (c *LRUCache[K, V]) PutIfAbsent(key K, value V) (V, bool) {
+	if entry, exists := c.entries.items[key]; exists {
+		c.entries.MoveToBack(key)
+		return entry.value, true
+	}
+	c.Put(key, value)
+	return value, false
+}
+
+/*
+O(1)
+Assumes: LRUCache c has been instantiated
+Maps the key to the value only if there already is a mapping with the supplied key
+Returns the replaced value and true, or the nil value of the value type and false if nothing was replaced
+A replaced entry becomes the most recently used
+*/
+func (c *LRUCache[K, V]) Replace(key K, value V) (V, bool) {
+	old, exists := c.entries.Replace(key, value)
+	if exists {
+		c.entries.MoveToBack(key)
+	}
+	return old, exists
+}
+
+/*
+O(1)
+Assumes: LRUCache c has been instantiated
+Removes the mapping with the supplied key without calling the eviction callback
+Returns the removed value and true, or the nil value of the value type and false if there was no mapping
+*/
+func (c *LRUCache[K, V]) Remove(key K) (V, bool) {
+	return c.entries.Remove(key)
+}
+
+/*
+O(n)
+Assumes: LRUCache c has been instantiated
+Returns the keys from the least to the most recently used
+*/
+func (c LRUCache[K, V]) Keys() []K {
+	return c.entries.Keys()
+}
+
+/*
+O(n)
+Assumes: LRUCache c has been instantiated
+Returns the values from the least to the most recently used
+*/
+func (c LRUCache[K, V]) Values() []V {
+	return c.entries.Values()
+}
+
+/*
+O(1)
+Assumes: LRUCache c has been instantiated
+Returns true if there exist a mapping with the supplied key
+Does not mark the entry as used
+*/
+func (c LRUCache[K, V]) ContainsKey(key K) bool {
+	return c.entries.ContainsKey(key)
+}
+
+/*
+O(1)
+Assumes: LRUCache c has been instantiated
+If there exists a mapping with the supplied key, the new value will be mergeOp(newVal, oldVal)
+If not, it works as a regular Put
+Either way the entry becomes the most recently used
+*/
+func (c *LRUCache[K, V]) Merge(key K, newVal V, mergeOp func(V, V) V) {
+	c.entries.Merge(key, newVal, mergeOp)
+	c.entries.MoveToBack(key)
+	c.evictOverflow()
+}
+
+/*
+O(1)
+Assumes: LRUCache c has been instantiated
+Updates the mapping with the supplied key
+remap receives the current value and whether it exists, and returns the new value
+and whether the mapping should exist afterwards, so returning false removes it
+Returns the value after the update and whether the mapping exists
+A kept mapping becomes the most recently used, a removed one is not reported to the eviction callback
+*/
+func (c *LRUCache[K, V]) Compute(key K, remap func(V, bool) (V, bool)) (V, bool) {
+	value, keep := c.entries.Compute(key, remap)
+	if keep {
+		c.entries.MoveToBack(key)
+		c.evictOverflow()
+	}
+	return value, keep
+}
+
+/*
+O(1)
+Assumes: LRUCache c has been instantiated
+Adds a mapping with the value returned by create if there is no mapping with the supplied key
+Nothing is added if create returns false
+Returns the value after the update and whether the mapping exists
+*/
+func (c *LRUCache[K, V]) ComputeIfAbsent(key K, create func() (V, bool)) (V, bool) {
+	return c.Compute(key, absentRemap(create))
+}
+
+/*
+O(1)
+Assumes: LRUCache c has been instantiated
+Replaces the value of an existing mapping with the value returned by remap
+The mapping is removed if remap returns false, and nothing happens if there is no mapping
+Returns the value after the update and whether the mapping exists
+*/
+func (c *LRUCache[K, V]) ComputeIfPresent(key K, remap func(V) (V, bool)) (V, bool) {
+	return c.Compute(key, presentRemap(remap))
+}
+
+/*
+O(1)
+Assumes: LRUCache c has been instantiated
+Returns the number of mappings
+*/
+func (c LRUCache[K, V]) Size() int {
+	return c.entries.Size()
+}
+
+/*
+O(1)
+Assumes: LRUCache c has been instantiated
+Returns the maximum number of mappings
+*/
+func (c LRUCache[K, V]) Capacity() int {
+	return c.capacity
+}
+
+/*
+O(k) where k is the number of evicted entries
+Assumes: LRUCache c has been instantiated
+Changes the capacity, evicting the least recently used entries if the cache no longer fits
+A capacity smaller than 1 is treated as 1
+*/
+func (c *LRUCache[K, V]) Resize(capacity int) {
+	c.capacity = max(capacity, 1)
+	c.evictOverflow()
+}
+
+/*
+O(1)
+Assumes: LRUCache c has been instantiated
+Returns the hit and miss counters of the lookups
+*/
+func (c LRUCache[K, V]) Stats() CacheStats {
+	return c.stats
+}
+
+/*
+O(1)
+Assumes: LRUCache c has been instantiated
+Sets the hit and miss counters to zero
+*/
+func (c *LRUCache[K, V]) ResetStats() {
+	c.stats = CacheStats{}
+}
+
+/*
+O(1)
+Removes every mapping without calling the eviction callback
+The statistics are kept
+*/
+func (c *LRUCache[K, V]) Clear() {
+	c.entries.Clear()
+}
+
+/*
+O(1)
+Returns the fraction of lookups that were hits, or 0 if there have been no lookups
+*/
+func (s CacheStats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// PRIVATE HELPER FUNCTIONS BELOW
+
+func (c *LRUCache[K, V]) evictOverflow() {
+	for c.entries.Size() > c.capacity {
+		oldest := c.entries.head
+		c.entries.Remove(oldest.key)
+		if c.onEvict != nil {
+			c.onEvict(oldest.key, oldest.value)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLRUCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLRUCache[int, string](2)
+	c.Put(1, "One")
+	c.Put(2, "Two")
+	c.Get(1)
+	c.Put(3, "Three")
+
+	if c.ContainsKey(2) {
+		t.Errorf("Expected key 2 to be evicted")
+	}
+	expected := []int{1, 3}
+	if !reflect.DeepEqual(c.Keys(), expected) {
+		t.Errorf("Expected keys %v, but got %v", expected, c.Keys())
+	}
+	if c.Size() != 2 {
+		t.Errorf("Expected size 2, but got %d", c.Size())
+	}
+}
+
+func TestLRUCache_PeekDoesNotPromote(t *testing.T) {
+	c := NewLRUCache[int, string](2)
+	c.Put(1, "One")
+	c.Put(2, "Two")
+
+	value, ok := c.Peek(1)
+	if !ok || value != "One" {
+		t.Errorf("Expected ('One', true), but got ('%s', %v)", value, ok)
+	}
+	if _, ok := c.Peek(5); ok {
+		t.Errorf("Expected Peek of a missing key to report false")
+	}
+
+	c.Put(3, "Three")
+	if c.ContainsKey(1) {
+		t.Errorf("Expected key 1 to be evicted since Peek does not count as a use")
+	}
+}
+
+func TestLRUCache_EvictionCallback(t *testing.T) {
+	evicted := make([]int, 0)
+	c := NewLRUCacheWithEvictionCallback[int, int](3, func(k int, v int) {
+		evicted = append(evicted, k)
+	})
+	for i := 0; i < 5; i++ {
+		c.Put(i, i)
+	}
+	// Explicit removal does not count as an eviction
+	c.Remove(4)
+
+	expected := []int{0, 1}
+	if !reflect.DeepEqual(evicted, expected) {
+		t.Errorf("Expected evictions %v, but got %v", expected, evicted)
+	}
+}
+
+func TestLRUCache_Stats(t *testing.T) {
+	c := NewLRUCache[string, int](4)
+	c.Put("a", 1)
+	c.Get("a")
+	c.Get("a")
+	c.Get("b")
+	c.Peek("b")
+	c.ContainsKey("b")
+
+	stats := c.Stats()
+	if stats.Hits != 2 || stats.Misses != 1 {
+		t.Errorf("Expected 2 hits and 1 miss, but got %+v", stats)
+	}
+	if ratio := stats.HitRatio(); ratio < 0.66 || ratio > 0.67 {
+		t.Errorf("Expected a hit ratio of 2/3, but got %f", ratio)
+	}
+
+	c.ResetStats()
+	if c.Stats() != (CacheStats{}) {
+		t.Errorf("Expected zeroed stats, but got %+v", c.Stats())
+	}
+}
+
+func TestLRUCache_Resize(t *testing.T) {
+	evicted := make([]int, 0)
+	c := NewLRUCacheWithEvictionCallback[int, int](5, func(k int, v int) {
+		evicted = append(evicted, k)
+	})
+	for i := 0; i < 5; i++ {
+		c.Put(i, i)
+	}
+
+	c.Resize(2)
+	expected := []int{0, 1, 2}
+	if !reflect.DeepEqual(evicted, expected) {
+		t.Errorf("Expected evictions %v, but got %v", expected, evicted)
+	}
+	if c.Capacity() != 2 || c.Size() != 2 {
+		t.Errorf("Expected capacity and size 2, but got %d and %d", c.Capacity(), c.Size())
+	}
+
+	c.Resize(10)
+	for i := 10; i < 18; i++ {
+		c.Put(i, i)
+	}
+	if c.Size() != 10 {
+		t.Errorf("Expected size 10 after growing, but got %d", c.Size())
+	}
+}
+
+func TestLRUCache_AsMap(t *testing.T) {
+	var m Map[string, int] = NewLRUCache[string, int](2)
+	sum := func(a, b int) int { return a + b }
+	m.Merge("a", 1, sum)
+	m.Merge("b", 1, sum)
+	m.Merge("a", 1, sum)
+	m.Merge("c", 1, sum)
+
+	if m.Get("a") != 2 || m.ContainsKey("b") || m.Get("c") != 1 {
+		t.Errorf("Unexpected contents: keys %v values %v", m.Keys(), m.Values())
+	}
+}
+
+func TestLRUCache_Compute(t *testing.T) {
+	evicted := make([]int, 0)
+	c := NewLRUCacheWithEvictionCallback(2, func(k int, _ int) {
+		evicted = append(evicted, k)
+	})
+	increment := func(old int, _ bool) (int, bool) { return old + 1, true }
+	c.Compute(1, increment)
+	c.Compute(2, increment)
+	// Updating 1 makes it the most recently used, so adding 3 evicts 2
+	c.Compute(1, increment)
+	c.Compute(3, increment)
+
+	if !reflect.DeepEqual(evicted, []int{2}) {
+		t.Errorf("Expected 2 to be evicted, but got %v", evicted)
+	}
+	expected := []int{1, 3}
+	if !reflect.DeepEqual(c.Keys(), expected) {
+		t.Errorf("Expected keys %v, but got %v", expected, c.Keys())
+	}
+	if value, _ := c.Peek(1); value != 2 {
+		t.Errorf("Expected 2 for key 1, but got %d", value)
+	}
+	c.ComputeIfPresent(1, func(int) (int, bool) { return 0, false })
+	if !reflect.DeepEqual(evicted, []int{2}) || c.Size() != 1 {
+		t.Errorf("Expected removing through Compute not to call the eviction callback")
+	}
+	if c.Stats() != (CacheStats{}) {
+		t.Errorf("Expected Compute not to count as a lookup, but got %+v", c.Stats())
+	}
+}
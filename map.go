@@ -1,16 +1,25 @@
 package utils
 
+import "iter"
+
 /*
  A generic Map interface
 */
 type Map[K comparable, V interface{}] interface {
 	Put(key K, value V)
 	Get(key K) V
-	Remove(key K)
+	GetOk(key K) (V, bool)
+	GetOrDefault(key K, defaultValue V) V
+	PutIfAbsent(key K, value V) (V, bool)
+	Replace(key K, value V) (V, bool)
+	Remove(key K) (V, bool)
 	Keys() []K
 	Values() []V
 	ContainsKey(key K) bool
 	Merge(key K, newValue V, mergeOp func(V, V) V)
+	Compute(key K, remap func(V, bool) (V, bool)) (V, bool)
+	ComputeIfAbsent(key K, create func() (V, bool)) (V, bool)
+	ComputeIfPresent(key K, remap func(V) (V, bool)) (V, bool)
 	Size() int
 }
 
@@ -51,14 +60,67 @@ func (m MapWrapper[K, V]) Get(key K) V {
 	return m.items[key]
 }
 
+/*
+ O(1)
+ Assumes: MapWrapper m has been instantiated
+ Returns the value that is associated with the supplied key and true,
+ or the nil value of the value type and false if there is no mapping
+*/
+func (m MapWrapper[K, V]) GetOk(key K) (V, bool) {
+	value, exists := m.items[key]
+	return value, exists
+}
+
+/*
+ O(1)
+ Assumes: MapWrapper m has been instantiated
+ Returns the value that is associated with the supplied key, or defaultValue if there is no mapping
+*/
+func (m MapWrapper[K, V]) GetOrDefault(key K, defaultValue V) V {
+	if value, exists := m.items[key]; exists {
+		return value
+	}
+	return defaultValue
+}
+
+/*
+ O(1)
+ Assumes: MapWrapper m has been instantiated
+ Maps the key to the value only if there is no mapping with the supplied key
+ Returns the value the key is mapped to afterwards and true if it was already mapped
+*/
+func (m *MapWrapper[K, V]) PutIfAbsent(key K, value V) (V, bool) {
+	if old, exists := m.items[key]; exists {
+		return old, true
+	}
+	m.items[key] = value
+	return value, false
+}
+
+/*
+ O(1)
+ Assumes: MapWrapper m has been instantiated
+ Maps the key to the value only if there already is a mapping with the supplied key
+ Returns the replaced value and true, or the nil value of the value type and false if nothing was replaced
+*/
+func (m *MapWrapper[K, V]) Replace(key K, value V) (V, bool) {
+	old, exists := m.items[key]
+	if exists {
+		m.items[key] = value
+	}
+	return old, exists
+}
+
 /*
  O(1)
  Assumes: MapWrapper m has been instantiated
  Removes the mapping with the supplied key
- No-op if such a mapping doesn't exist
+ Returns the removed value and true, or the nil value of the value type and false if there was no mapping
 */
-func (m *MapWrapper[K, V]) Remove(key K) {
+func (m *MapWrapper[K, V]) Remove(key K) (V, bool) {
+	value, exists := m.items[key]
 	delete(m.items, key)
+	return value, exists
 }
 
 /*
@@ -111,6 +173,48 @@ func (m *MapWrapper[K, V]) Merge(key K, newVal V, mergeOp func(V, V) V) {
 	}
 }
 
+/*
+ O(1)
+ Assumes: MapWrapper m has been instantiated
+ Updates the mapping with the supplied key in a single lookup
+ remap receives the current value and whether it exists, and returns the new value
+ and whether the mapping should exist afterwards, so returning false removes it
+ Returns the value after the update and whether the mapping exists
+*/
+func (m *MapWrapper[K, V]) Compute(key K, remap func(V, bool) (V, bool)) (V, bool) {
+	old, exists := m.items[key]
+	value, keep := remap(old, exists)
+	if !keep {
+		delete(m.items, key)
+		var nilVal V
+		return nilVal, false
+	}
+	m.items[key] = value
+	return value, true
+}
+
+/*
+ O(1)
+ Assumes: MapWrapper m has been instantiated
+ Adds a mapping with the value returned by create if there is no mapping with the supplied key
+ Nothing is added if create returns false
+ Returns the value after the update and whether the mapping exists
+*/
+func (m *MapWrapper[K, V]) ComputeIfAbsent(key K, create func() (V, bool)) (V, bool) {
+	return m.Compute(key, absentRemap(create))
+}
+
+/*
+ O(1)
+ Assumes: MapWrapper m has been instantiated
+ Replaces the value of an existing mapping with the value returned by remap
+ The mapping is removed if remap returns false, and nothing happens if there is no mapping
+ Returns the value after the update and whether the mapping exists
+*/
+func (m *MapWrapper[K, V]) ComputeIfPresent(key K, remap func(V) (V, bool)) (V, bool) {
+	return m.Compute(key, presentRemap(remap))
+}
+
 /*
  Assumes: MapWrapper m has been instantiated
  Returns the number of mappings
@@ -118,3 +222,84 @@ func (m *MapWrapper[K, V]) Merge(key K, newVal V, mergeOp func(V, V) V) {
 func (m MapWrapper[K, V]) Size() int {
 	return len(m.items)
 }
+
+/*
+O(1)
+Assumes: MapWrapper m has been instantiated
+Returns an iterator over the mappings in no particular order
+The map must not be modified during the iteration
+*/
+func (m MapWrapper[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range m.items {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+/*
+O(1)
+Assumes: MapWrapper m has been instantiated
+Returns an iterator over the keys in no particular order, without allocating a slice like Keys does
+*/
+func (m MapWrapper[K, V]) KeysSeq() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range m.items {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+/*
+O(1)
+Assumes: MapWrapper m has been instantiated
+Returns an iterator over the values in no particular order, without allocating a slice like Values does
+*/
+func (m MapWrapper[K, V]) ValuesSeq() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range m.items {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+/*
+O(n)
+Instantiates a new MapWrapper holding the mappings of the iterator
+Later mappings overwrite earlier mappings with the same key
+*/
+func CollectMap[K comparable, V interface{}](seq iter.Seq2[K, V]) *MapWrapper[K, V] {
+	m := NewMapWrapper[K, V]()
+	for k, v := range seq {
+		m.Put(k, v)
+	}
+	return m
+}
+
+// PRIVATE HELPER FUNCTIONS BELOW
+
+// adapts a ComputeIfAbsent function to Compute
+func absentRemap[V interface{}](create func() (V, bool)) func(V, bool) (V, bool) {
+	return func(old V, exists bool) (V, bool) {
+		if exists {
+			return old, true
+		}
+		return create()
+	}
+}
+
+// adapts a ComputeIfPresent function to Compute
+func presentRemap[V interface{}](remap func(V) (V, bool)) func(V, bool) (V, bool) {
+	return func(old V, exists bool) (V, bool) {
+		if !exists {
+			return old, false
+		}
+		return remap(old)
+	}
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"testing"
+	"time"
 )
 
 func TestMapWrapper_Put(t *testing.T) {
@@ -242,3 +243,176 @@ func TestMapWrapper_SizeNonEmptyMap(t *testing.T) {
 		t.Errorf("Expected size 2 for a map with 2 mappings, but got %d", size)
 	}
 }
+
+func TestMapWrapper_Iterators(t *testing.T) {
+	// Build a map from an iterator and walk it back
+	m := CollectMap(func(yield func(int, string) bool) {
+		for i, s := range []string{"Zero", "One", "Two"} {
+			if !yield(i, s) {
+				return
+			}
+		}
+	})
+
+	seen := make(map[int]string)
+	for k, v := range m.All() {
+		seen[k] = v
+	}
+	if len(seen) != 3 || seen[1] != "One" {
+		t.Errorf("Expected All to visit every mapping, but got %v", seen)
+	}
+
+	keySum := 0
+	for k := range m.KeysSeq() {
+		keySum += k
+	}
+	if keySum != 3 {
+		t.Errorf("Expected the keys to sum to 3, but got %d", keySum)
+	}
+
+	count := 0
+	for range m.ValuesSeq() {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("Expected the iteration to stop after break, but got %d values", count)
+	}
+}
+
+// runs the behaviour every Map implementation must share against the maps made by newMap
+func testMapConformance(t *testing.T, newMap func() Map[int, string]) {
+	t.Run("GetOk", func(t *testing.T) {
+		m := newMap()
+		m.Put(1, "")
+		if value, ok := m.GetOk(1); !ok || value != "" {
+			t.Errorf("Expected ('', true) for a key mapped to the nil value, but got ('%s', %v)", value, ok)
+		}
+		if _, ok := m.GetOk(2); ok {
+			t.Errorf("Expected false for a missing key")
+		}
+	})
+
+	t.Run("GetOrDefault", func(t *testing.T) {
+		m := newMap()
+		m.Put(1, "One")
+		if value := m.GetOrDefault(1, "Default"); value != "One" {
+			t.Errorf("Expected 'One', but got '%s'", value)
+		}
+		if value := m.GetOrDefault(2, "Default"); value != "Default" {
+			t.Errorf("Expected 'Default', but got '%s'", value)
+		}
+	})
+
+	t.Run("PutIfAbsent", func(t *testing.T) {
+		m := newMap()
+		if value, existed := m.PutIfAbsent(1, "One"); existed || value != "One" {
+			t.Errorf("Expected ('One', false) when absent, but got ('%s', %v)", value, existed)
+		}
+		if value, existed := m.PutIfAbsent(1, "NewOne"); !existed || value != "One" {
+			t.Errorf("Expected ('One', true) when present, but got ('%s', %v)", value, existed)
+		}
+		if m.Get(1) != "One" || m.Size() != 1 {
+			t.Errorf("Expected PutIfAbsent to keep the existing value, but got '%s'", m.Get(1))
+		}
+	})
+
+	t.Run("Replace", func(t *testing.T) {
+		m := newMap()
+		if _, replaced := m.Replace(1, "One"); replaced || m.ContainsKey(1) {
+			t.Errorf("Expected Replace of a missing key to do nothing")
+		}
+		m.Put(1, "One")
+		if old, replaced := m.Replace(1, "NewOne"); !replaced || old != "One" {
+			t.Errorf("Expected ('One', true), but got ('%s', %v)", old, replaced)
+		}
+		if m.Get(1) != "NewOne" {
+			t.Errorf("Expected 'NewOne', but got '%s'", m.Get(1))
+		}
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		m := newMap()
+		m.Put(1, "One")
+		if value, removed := m.Remove(1); !removed || value != "One" {
+			t.Errorf("Expected ('One', true), but got ('%s', %v)", value, removed)
+		}
+		if _, removed := m.Remove(1); removed {
+			t.Errorf("Expected false when removing a missing key")
+		}
+		if m.Size() != 0 {
+			t.Errorf("Expected size 0, but got %d", m.Size())
+		}
+	})
+
+	t.Run("Compute", func(t *testing.T) {
+		m := newMap()
+		appendX := func(old string, exists bool) (string, bool) {
+			return old + "x", true
+		}
+		m.Compute(1, appendX)
+		if value, exists := m.Compute(1, appendX); !exists || value != "xx" {
+			t.Errorf("Expected ('xx', true), but got ('%s', %v)", value, exists)
+		}
+		if value, exists := m.Compute(1, func(string, bool) (string, bool) { return "", false }); exists || value != "" {
+			t.Errorf("Expected ('', false) after removing, but got ('%s', %v)", value, exists)
+		}
+		if m.ContainsKey(1) || m.Size() != 0 {
+			t.Errorf("Expected Compute returning false to remove the mapping")
+		}
+		m.Compute(2, func(old string, exists bool) (string, bool) { return "Two", exists })
+		if m.ContainsKey(2) {
+			t.Errorf("Expected Compute returning false for a missing key to add nothing")
+		}
+	})
+
+	t.Run("ComputeIfAbsent", func(t *testing.T) {
+		m := newMap()
+		calls := 0
+		create := func() (string, bool) {
+			calls++
+			return "One", true
+		}
+		m.ComputeIfAbsent(1, create)
+		if value, exists := m.ComputeIfAbsent(1, create); !exists || value != "One" || calls != 1 {
+			t.Errorf("Expected ('One', true) with one call, but got ('%s', %v) with %d calls", value, exists, calls)
+		}
+		if _, exists := m.ComputeIfAbsent(2, func() (string, bool) { return "Two", false }); exists || m.ContainsKey(2) {
+			t.Errorf("Expected ComputeIfAbsent returning false to add nothing")
+		}
+	})
+
+	t.Run("ComputeIfPresent", func(t *testing.T) {
+		m := newMap()
+		exclaim := func(old string) (string, bool) { return old + "!", true }
+		if _, exists := m.ComputeIfPresent(1, exclaim); exists || m.ContainsKey(1) {
+			t.Errorf("Expected ComputeIfPresent of a missing key to do nothing")
+		}
+		m.Put(1, "One")
+		if value, exists := m.ComputeIfPresent(1, exclaim); !exists || value != "One!" {
+			t.Errorf("Expected ('One!', true), but got ('%s', %v)", value, exists)
+		}
+		m.ComputeIfPresent(1, func(string) (string, bool) { return "", false })
+		if m.ContainsKey(1) {
+			t.Errorf("Expected ComputeIfPresent returning false to remove the mapping")
+		}
+	})
+}
+
+func TestMap_Conformance(t *testing.T) {
+	implementations := map[string]func() Map[int, string]{
+		"MapWrapper":    func() Map[int, string] { return NewMapWrapper[int, string]() },
+		"TreeMap":       func() Map[int, string] { return NewTreeMap[int, string](cmp) },
+		"LinkedHashMap": func() Map[int, string] { return NewLinkedHashMap[int, string]() },
+		"LRUCache":      func() Map[int, string] { return NewLRUCache[int, string](16) },
+		"TTLMap":        func() Map[int, string] { return NewTTLMap[int, string](time.Hour) },
+		"ConcurrentMap": func() Map[int, string] { return NewConcurrentMap[int, string]() },
+		"BiMap":         func() Map[int, string] { return NewBiMap[int, string]() },
+		"BiMapInverse":  func() Map[int, string] { return NewBiMap[string, int]().Inverse() },
+	}
+	for name, newMap := range implementations {
+		t.Run(name, func(t *testing.T) {
+			testMapConformance(t, newMap)
+		})
+	}
+}
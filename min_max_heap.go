@@ -0,0 +1,395 @@
+package utils
+
+import (
+	"iter"
+	"math/bits"
+)
+
+/*
+A double-ended priority queue giving access to both the smallest and the largest item
+Implements the Queue interface with min-first semantics, so Dequeue and Peek work like PopMin and PeekMin.
+The heap alternates between min levels and max levels: every item on an even level is smaller than or equal
+to its descendants and every item on an odd level is larger than or equal to its descendants
+*/
+type MinMaxHeap[T interface{}] struct {
+	comparator func(T, T) int
+	contents   []T
+}
+
+/*
+O(1)
+Instantiates a new empty MinMaxHeap ordered by comp
+*/
+func NewMinMaxHeap[T interface{}](comp func(T, T) int) *MinMaxHeap[T] {
+	return &MinMaxHeap[T]{
+		comparator: comp,
+		contents:   make([]T, 0)}
+}
+
+/*
+O(n)
+Instantiates a new MinMaxHeap holding the supplied items
+The slice is copied, so the caller may keep using it
+*/
+func NewMinMaxHeapFrom[T interface{}](items []T, comp func(T, T) int) *MinMaxHeap[T] {
+	h := &MinMaxHeap[T]{
+		comparator: comp,
+		contents:   make([]T, len(items))}
+	copy(h.contents, items)
+	h.heapify()
+	return h
+}
+
+/*
+O(log n)
+Assumes: MinMaxHeap h has been instantiated
+Inserts the item
+*/
+func (h *MinMaxHeap[T]) Enqueue(item T) {
+	h.contents = append(h.contents, item)
+	h.pushUp(len(h.contents) - 1)
+}
+
+/*
+O(min(m log(n + m), n + m)) where m is the number of items
+Assumes: MinMaxHeap h has been instantiated
+Enqueues all items
+Rebuilds the whole heap bottom-up when that is cheaper than enqueueing the items one by one
+*/
+func (h *MinMaxHeap[T]) EnqueueAll(items []T) {
+	n := len(h.contents) + len(items)
+	if len(items)*bits.Len(uint(n)) > n {
+		h.contents = append(h.contents, items...)
+		h.heapify()
+		return
+	}
+	for _, item := range items {
+		h.Enqueue(item)
+	}
+}
+
+/*
+O(1)
+Assumes: MinMaxHeap h has been instantiated
+Returns the smallest item without removing it
+Returns error if the heap is empty
+*/
+func (h MinMaxHeap[T]) PeekMin() (T, error) {
+	if len(h.contents) == 0 {
+		var nilVal T
+		return nilVal, &OpError{Op: "MinMaxHeap.PeekMin", Err: ErrEmpty}
+	}
+	return h.contents[0], nil
+}
+
+/*
+O(1)
+Assumes: MinMaxHeap h has been instantiated
+Returns the largest item without removing it
+Returns error if the heap is empty
+*/
+func (h MinMaxHeap[T]) PeekMax() (T, error) {
+	if len(h.contents) == 0 {
+		var nilVal T
+		return nilVal, &OpError{Op: "MinMaxHeap.PeekMax", Err: ErrEmpty}
+	}
+	return h.contents[h.maxIndex()], nil
+}
+
+/*
+O(log n)
+Assumes: MinMaxHeap h has been instantiated
+Removes and returns the smallest item
+Returns error if the heap is empty
+*/
+func (h *MinMaxHeap[T]) PopMin() (T, error) {
+	if len(h.contents) == 0 {
+		var nilVal T
+		return nilVal, &OpError{Op: "MinMaxHeap.PopMin", Err: ErrEmpty}
+	}
+	return h.removeAt(0), nil
+}
+
+/*
+O(log n)
+Assumes: MinMaxHeap h has been instantiated
+Removes and returns the largest item
+Returns error if the heap is empty
+*/
+func (h *MinMaxHeap[T]) PopMax() (T, error) {
+	if len(h.contents) == 0 {
+		var nilVal T
+		return nilVal, &OpError{Op: "MinMaxHeap.PopMax", Err: ErrEmpty}
+	}
+	return h.removeAt(h.maxIndex()), nil
+}
+
+/*
+O(log n)
+Assumes: MinMaxHeap h has been instantiated
+Queue operation: removes and returns the smallest item, same as PopMin
+*/
+func (h *MinMaxHeap[T]) Dequeue() (T, error) {
+	if len(h.contents) == 0 {
+		var nilVal T
+		return nilVal, &OpError{Op: "MinMaxHeap.Dequeue", Err: ErrEmpty}
+	}
+	return h.removeAt(0), nil
+}
+
+/*
+O(n log n)
+Assumes: MinMaxHeap h has been instantiated
+Removes all items and returns them from the smallest to the largest
+*/
+func (h *MinMaxHeap[T]) DequeueAll() []T {
+	out := make([]T, 0, len(h.contents))
+	for len(h.contents) > 0 {
+		out = append(out, h.removeAt(0))
+	}
+	return out
+}
+
+/*
+O(1)
+Assumes: MinMaxHeap h has been instantiated
+Queue operation: returns the smallest item without removing it, same as PeekMin
+*/
+func (h MinMaxHeap[T]) Peek() (T, error) {
+	if len(h.contents) == 0 {
+		var nilVal T
+		return nilVal, &OpError{Op: "MinMaxHeap.Peek", Err: ErrEmpty}
+	}
+	return h.contents[0], nil
+}
+
+/*
+O(1)
+Assumes: MinMaxHeap h has been instantiated
+Returns true if the heap is empty
+*/
+func (h MinMaxHeap[T]) IsEmpty() bool {
+	return len(h.contents) == 0
+}
+
+/*
+O(1)
+Assumes: MinMaxHeap h has been instantiated
+Returns the number of items
+*/
+func (h MinMaxHeap[T]) Size() int {
+	return len(h.contents)
+}
+
+/*
+O(1)
+Wipes the contents of the heap
+*/
+func (h *MinMaxHeap[T]) Clear() {
+	h.contents = make([]T, 0)
+}
+
+/*
+O(n)
+Assumes: MinMaxHeap h has been instantiated
+Returns the inner representation of the heap as a slice
+*/
+func (h MinMaxHeap[T]) ToSlice() []T {
+	out := make([]T, len(h.contents))
+	copy(out, h.contents)
+	return out
+}
+
+/*
+O(n + k log n) for the first k items
+Assumes: MinMaxHeap h has been instantiated
+Returns an iterator over the ranks and items from the smallest to the largest
+The heap itself is left untouched, the iterator drains a copy as it goes
+*/
+func (h MinMaxHeap[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		heap := h.clone()
+		for i := 0; !heap.IsEmpty(); i++ {
+			if !yield(i, heap.removeAt(0)) {
+				return
+			}
+		}
+	}
+}
+
+/*
+O(n + k log n) for the first k items
+Assumes: MinMaxHeap h has been instantiated
+Returns an iterator over the ranks and items from the largest to the smallest
+The heap itself is left untouched, the iterator drains a copy as it goes
+*/
+func (h MinMaxHeap[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		heap := h.clone()
+		for i := heap.Size() - 1; i >= 0; i-- {
+			if !yield(i, heap.removeAt(heap.maxIndex())) {
+				return
+			}
+		}
+	}
+}
+
+/*
+O(n)
+Instantiates a new MinMaxHeap holding the items of the iterator
+*/
+func CollectMinMaxHeap[T interface{}](seq iter.Seq[T], comp func(T, T) int) *MinMaxHeap[T] {
+	h := NewMinMaxHeap(comp)
+	for item := range seq {
+		h.contents = append(h.contents, item)
+	}
+	h.heapify()
+	return h
+}
+
+// PRIVATE HELPER FUNCTIONS BELOW
+
+func (h MinMaxHeap[T]) clone() *MinMaxHeap[T] {
+	contents := make([]T, len(h.contents))
+	copy(contents, h.contents)
+	return &MinMaxHeap[T]{comparator: h.comparator, contents: contents}
+}
+
+// assumes the heap is not empty, the largest item is the root or one of its children
+func (h MinMaxHeap[T]) maxIndex() int {
+	switch len(h.contents) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+	if h.greater(2, 1) {
+		return 2
+	}
+	return 1
+}
+
+func (h *MinMaxHeap[T]) removeAt(pos int) T {
+	item := h.contents[pos]
+	last := len(h.contents) - 1
+	h.contents[pos] = h.contents[last]
+	h.contents = h.contents[:last]
+	if pos < last {
+		h.pushDown(pos)
+	}
+	return item
+}
+
+// bottom-up heap construction, O(n)
+func (h *MinMaxHeap[T]) heapify() {
+	for pos := len(h.contents)/2 - 1; pos >= 0; pos-- {
+		h.pushDown(pos)
+	}
+}
+
+func (h *MinMaxHeap[T]) pushUp(pos int) {
+	if pos == 0 {
+		return
+	}
+	parent := getParentIndex(pos)
+	if isMinLevel(pos) {
+		if h.greater(pos, parent) {
+			h.swap(pos, parent)
+			h.pushUpWhile(parent, h.greater)
+		} else {
+			h.pushUpWhile(pos, h.less)
+		}
+	} else {
+		if h.less(pos, parent) {
+			h.swap(pos, parent)
+			h.pushUpWhile(parent, h.less)
+		} else {
+			h.pushUpWhile(pos, h.greater)
+		}
+	}
+}
+
+// moves the item up through the levels of its own kind while it should be above its grandparent
+func (h *MinMaxHeap[T]) pushUpWhile(pos int, before func(int, int) bool) {
+	for pos > 2 {
+		grandparent := getParentIndex(getParentIndex(pos))
+		if !before(pos, grandparent) {
+			return
+		}
+		h.swap(pos, grandparent)
+		pos = grandparent
+	}
+}
+
+func (h *MinMaxHeap[T]) pushDown(pos int) {
+	if isMinLevel(pos) {
+		h.pushDownWhile(pos, h.less)
+	} else {
+		h.pushDownWhile(pos, h.greater)
+	}
+}
+
+// moves the item down through the levels of its own kind, before is less on min levels and greater on max levels
+func (h *MinMaxHeap[T]) pushDownWhile(pos int, before func(int, int) bool) {
+	for {
+		best := h.bestDescendant(pos, before)
+		if best < 0 || !before(best, pos) {
+			return
+		}
+		h.swap(best, pos)
+		if best <= getRightChild(pos) {
+			// a child is on the other kind of level, so nothing can be below it that belongs above pos
+			return
+		}
+		if parent := getParentIndex(best); before(parent, best) {
+			h.swap(best, parent)
+		}
+		pos = best
+	}
+}
+
+// index of the child or grandchild that comes first, or -1 if there are no children
+func (h MinMaxHeap[T]) bestDescendant(pos int, before func(int, int) bool) int {
+	left := getLeftChild(pos)
+	if left >= len(h.contents) {
+		return -1
+	}
+	right := getRightChild(pos)
+	best := left
+	candidates := [...]int{right, getLeftChild(left), getRightChild(left), getLeftChild(right), getRightChild(right)}
+	for _, i := range candidates {
+		if i < len(h.contents) && before(i, best) {
+			best = i
+		}
+	}
+	return best
+}
+
+func (h MinMaxHeap[T]) less(a, b int) bool {
+	return h.comparator(h.contents[a], h.contents[b]) < 0
+}
+
+func (h MinMaxHeap[T]) greater(a, b int) bool {
+	return h.comparator(h.contents[a], h.contents[b]) > 0
+}
+
+func (h *MinMaxHeap[T]) swap(a, b int) {
+	h.contents[a], h.contents[b] = h.contents[b], h.contents[a]
+}
+
+// the root is on level 0, which is a min level
+func isMinLevel(pos int) bool {
+	return bits.Len(uint(pos+1))%2 == 1
+}
+
+func getLeftChild(i int) int {
+	return 2*i + 1
+}
+
+func getRightChild(i int) int {
+	return 2*i + 2
+}
+
+func getParentIndex(i int) int {
+	return (i - 1) / 2
+}
@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestMinMaxHeap_PeekAndPop(t *testing.T) {
+	h := NewMinMaxHeap(cmp)
+	for _, item := range []int{5, 9, 1, 7, 3, 8, 2} {
+		h.Enqueue(item)
+	}
+
+	smallest, _ := h.PeekMin()
+	largest, _ := h.PeekMax()
+	if smallest != 1 || largest != 9 {
+		t.Errorf("Expected min 1 and max 9, but got %d and %d", smallest, largest)
+	}
+	popped := make([]int, 0)
+	for !h.IsEmpty() {
+		item, _ := h.PopMax()
+		popped = append(popped, item)
+		if item, err := h.PopMin(); err == nil {
+			popped = append(popped, item)
+		}
+	}
+	expected := []int{9, 1, 8, 2, 7, 3, 5}
+	if !reflect.DeepEqual(popped, expected) {
+		t.Errorf("Expected %v, but got %v", expected, popped)
+	}
+}
+
+func TestMinMaxHeap_EmptyErrors(t *testing.T) {
+	h := NewMinMaxHeap(cmp)
+	if _, err := h.PeekMin(); err == nil {
+		t.Errorf("PeekMin should return an error when the heap is empty")
+	}
+	if _, err := h.PeekMax(); err == nil {
+		t.Errorf("PeekMax should return an error when the heap is empty")
+	}
+	if _, err := h.PopMin(); err == nil {
+		t.Errorf("PopMin should return an error when the heap is empty")
+	}
+	if _, err := h.PopMax(); err == nil {
+		t.Errorf("PopMax should return an error when the heap is empty")
+	}
+}
+
+func TestMinMaxHeap_AsQueue(t *testing.T) {
+	var q Queue[int] = NewMinMaxHeapFrom([]int{4, 2, 6}, cmp)
+	q.EnqueueAll([]int{5, 1, 3})
+	front, _ := q.Peek()
+	if front != 1 {
+		t.Errorf("Expected front 1, but got %d", front)
+	}
+	expected := []int{1, 2, 3, 4, 5, 6}
+	if items := q.DequeueAll(); !reflect.DeepEqual(items, expected) {
+		t.Errorf("Expected %v, but got %v", expected, items)
+	}
+	if _, err := q.Dequeue(); err == nil {
+		t.Errorf("Dequeue should return an error when the queue is empty")
+	}
+}
+
+func TestMinMaxHeap_RandomOperations(t *testing.T) {
+	h := NewMinMaxHeap(cmp)
+	reference := make([]int, 0)
+	for i := 0; i < 5000; i++ {
+		switch rand.Intn(4) {
+		case 0:
+			item, err := h.PopMin()
+			if len(reference) > 0 {
+				if err != nil || item != reference[0] {
+					t.Fatalf("Expected min %d, but got %d (err: %v)", reference[0], item, err)
+				}
+				reference = reference[1:]
+			}
+		case 1:
+			item, err := h.PopMax()
+			if len(reference) > 0 {
+				if err != nil || item != reference[len(reference)-1] {
+					t.Fatalf("Expected max %d, but got %d (err: %v)", reference[len(reference)-1], item, err)
+				}
+				reference = reference[:len(reference)-1]
+			}
+		default:
+			item := rand.Intn(1000)
+			h.Enqueue(item)
+			i, _ := slices.BinarySearch(reference, item)
+			reference = slices.Insert(reference, i, item)
+		}
+		if err := minMaxInvariant(h); err != nil {
+			t.Fatalf("%s", err.Error())
+		}
+	}
+
+	built := NewMinMaxHeapFrom(h.ToSlice(), cmp)
+	if err := minMaxInvariant(built); err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if items := h.DequeueAll(); !reflect.DeepEqual(items, reference) {
+		t.Errorf("Expected %v, but got %v", reference, items)
+	}
+}
+
+func TestMinMaxHeap_Iterators(t *testing.T) {
+	h := CollectMinMaxHeap(slices.Values([]int{3, 1, 4, 5, 2}), cmp)
+
+	ascending := make([]int, 0)
+	for rank, item := range h.All() {
+		if rank != len(ascending) {
+			t.Errorf("Expected rank %d, but got %d", len(ascending), rank)
+		}
+		ascending = append(ascending, item)
+	}
+	if !reflect.DeepEqual(ascending, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("Expected All from the smallest to the largest, but got %v", ascending)
+	}
+	for rank, item := range h.Backward() {
+		if item != rank+1 {
+			t.Errorf("Expected item %d at rank %d, but got %d", rank+1, rank, item)
+		}
+		if item == 4 {
+			break
+		}
+	}
+	if h.Size() != 5 {
+		t.Errorf("Expected the iterators to leave the heap untouched, but got size %d", h.Size())
+	}
+}
+
+// checks that items on min levels are at most their descendants and items on max levels at least their descendants
+func minMaxInvariant[T interface{}](h *MinMaxHeap[T]) error {
+	for i := 1; i < len(h.contents); i++ {
+		for ancestor := getParentIndex(i); ; ancestor = getParentIndex(ancestor) {
+			order := h.comparator(h.contents[ancestor], h.contents[i])
+			if (isMinLevel(ancestor) && order > 0) || (!isMinLevel(ancestor) && order < 0) {
+				return errors.New("Min-max heap invariant broken")
+			}
+			if ancestor == 0 {
+				break
+			}
+		}
+	}
+	return nil
+}
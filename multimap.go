@@ -0,0 +1,514 @@
+package utils
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+)
+
+/*
+A map where every key is associated with a list of values
+The same value may be associated with a key several times, and the values of a key keep their insertion order.
+Keys without values are removed, so a key is present exactly when it has at least one value
+*/
+type ListMultimap[K comparable, V comparable] struct {
+	items map[K][]V
+	size  int
+}
+
+/*
+A read-only view of the values associated with one key of a ListMultimap
+The view is live: it always reflects the current values of the key, also after they are changed through the multimap
+*/
+type ListView[K comparable, V comparable] struct {
+	owner *ListMultimap[K, V]
+	key   K
+}
+
+/*
+A map where every key is associated with a set of values
+Adding a value that is already associated with the key is a no-op.
+Keys without values are removed, so a key is present exactly when it has at least one value
+*/
+type SetMultimap[K comparable, V comparable] struct {
+	items map[K]*HashSet[V]
+	size  int
+}
+
+/*
+A read-only view of the values associated with one key of a SetMultimap
+The view is live: it always reflects the current values of the key, also after they are changed through the multimap
+*/
+type SetView[K comparable, V comparable] struct {
+	owner *SetMultimap[K, V]
+	key   K
+}
+
+/*
+O(1)
+Instantiates a new empty ListMultimap
+*/
+func NewListMultimap[K comparable, V comparable]() *ListMultimap[K, V] {
+	return &ListMultimap[K, V]{items: make(map[K][]V)}
+}
+
+/*
+O(1) amortized
+Assumes: ListMultimap m has been instantiated
+Appends the value to the values of the key
+Always returns true since a list accepts duplicates
+*/
+func (m *ListMultimap[K, V]) Put(key K, value V) bool {
+	m.items[key] = append(m.items[key], value)
+	m.size++
+	return true
+}
+
+/*
+O(k) where k is the number of values
+Assumes: ListMultimap m has been instantiated
+Appends the values to the values of the key
+Returns the number of values that were added
+*/
+func (m *ListMultimap[K, V]) PutAll(key K, values []V) int {
+	if len(values) == 0 {
+		return 0
+	}
+	m.items[key] = append(m.items[key], values...)
+	m.size += len(values)
+	return len(values)
+}
+
+/*
+O(1)
+Assumes: ListMultimap m has been instantiated
+Returns a live read-only view of the values of the key
+The view is empty while the key has no values
+*/
+func (m *ListMultimap[K, V]) Get(key K) ListView[K, V] {
+	return ListView[K, V]{owner: m, key: key}
+}
+
+/*
+O(k) where k is the number of values of the key
+Assumes: ListMultimap m has been instantiated
+Removes the first occurrence of the value from the values of the key
+Returns true if the value was there
+*/
+func (m *ListMultimap[K, V]) RemoveValue(key K, value V) bool {
+	values := m.items[key]
+	i := slices.Index(values, value)
+	if i < 0 {
+		return false
+	}
+	values = slices.Delete(values, i, i+1)
+	m.size--
+	if len(values) == 0 {
+		delete(m.items, key)
+	} else {
+		m.items[key] = values
+	}
+	return true
+}
+
+/*
+O(1)
+Assumes: ListMultimap m has been instantiated
+Removes the key together with all of its values
+Returns the removed values in insertion order, or nil if the key had no values
+*/
+func (m *ListMultimap[K, V]) RemoveAll(key K) []V {
+	values, exists := m.items[key]
+	if !exists {
+		return nil
+	}
+	delete(m.items, key)
+	m.size -= len(values)
+	return values
+}
+
+/*
+O(1)
+Assumes: ListMultimap m has been instantiated
+Returns true if the key has at least one value
+*/
+func (m ListMultimap[K, V]) ContainsKey(key K) bool {
+	_, exists := m.items[key]
+	return exists
+}
+
+/*
+O(k) where k is the number of values of the key
+Assumes: ListMultimap m has been instantiated
+Returns true if the value is associated with the key
+*/
+func (m ListMultimap[K, V]) ContainsEntry(key K, value V) bool {
+	return slices.Contains(m.items[key], value)
+}
+
+/*
+O(n)
+Assumes: ListMultimap m has been instantiated
+Returns the keys that have at least one value, in no particular order
+*/
+func (m ListMultimap[K, V]) Keys() []K {
+	keys := make([]K, 0, len(m.items))
+	for k := range m.items {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+/*
+O(1)
+Assumes: ListMultimap m has been instantiated
+Returns the total number of key-value pairs, counting duplicates
+*/
+func (m ListMultimap[K, V]) Size() int {
+	return m.size
+}
+
+/*
+O(1)
+Assumes: ListMultimap m has been instantiated
+Returns the number of distinct keys
+*/
+func (m ListMultimap[K, V]) KeyCount() int {
+	return len(m.items)
+}
+
+/*
+O(1)
+Assumes: ListMultimap m has been instantiated
+Removes every key and value
+*/
+func (m *ListMultimap[K, V]) Clear() {
+	m.items = make(map[K][]V)
+	m.size = 0
+}
+
+/*
+O(n)
+Assumes: ListMultimap m has been instantiated
+Returns a new multimap where every value is associated with the keys it was associated with
+A value associated with a key several times gets that key several times
+*/
+func (m ListMultimap[K, V]) Invert() *ListMultimap[V, K] {
+	inverse := NewListMultimap[V, K]()
+	for k, v := range m.All() {
+		inverse.Put(v, k)
+	}
+	return inverse
+}
+
+/*
+O(1)
+Assumes: ListMultimap m has been instantiated
+Returns an iterator over every key-value pair, with the keys in no particular order
+and the values of a key in insertion order
+The multimap must not be modified during the iteration
+*/
+func (m ListMultimap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, values := range m.items {
+			for _, v := range values {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+/*
+O(1)
+Returns the number of values of the key
+*/
+func (v ListView[K, V]) Size() int {
+	return len(v.owner.items[v.key])
+}
+
+/*
+O(1)
+Returns true if the key has no values
+*/
+func (v ListView[K, V]) IsEmpty() bool {
+	return v.Size() == 0
+}
+
+/*
+O(1)
+Returns the i:th value of the key in insertion order
+Returns error if i is out of range
+*/
+func (v ListView[K, V]) At(i int) (V, error) {
+	values := v.owner.items[v.key]
+	if i < 0 || i >= len(values) {
+		var nilVal V
+		return nilVal, &OpError{Op: "ListView.At", Err: fmt.Errorf("%w: %d for size %d", ErrOutOfRange, i, len(values))}
+	}
+	return values[i], nil
+}
+
+/*
+O(k) where k is the number of values of the key
+Returns true if the value is associated with the key
+*/
+func (v ListView[K, V]) Contains(value V) bool {
+	return v.owner.ContainsEntry(v.key, value)
+}
+
+/*
+O(k) where k is the number of values of the key
+Returns a copy of the values of the key in insertion order
+*/
+func (v ListView[K, V]) ToSlice() []V {
+	return slices.Clone(v.owner.items[v.key])
+}
+
+/*
+O(1)
+Returns an iterator over the values of the key in insertion order
+The multimap must not be modified during the iteration
+*/
+func (v ListView[K, V]) Values() iter.Seq[V] {
+	return slices.Values(v.owner.items[v.key])
+}
+
+/*
+O(1)
+Instantiates a new empty SetMultimap
+*/
+func NewSetMultimap[K comparable, V comparable]() *SetMultimap[K, V] {
+	return &SetMultimap[K, V]{items: make(map[K]*HashSet[V])}
+}
+
+/*
+O(1)
+Assumes: SetMultimap m has been instantiated
+Associates the value with the key
+Returns true if the value wasn't associated with the key before
+*/
+func (m *SetMultimap[K, V]) Put(key K, value V) bool {
+	values, exists := m.items[key]
+	if !exists {
+		values = NewHashSet[V]()
+		m.items[key] = values
+	}
+	if !values.Add(value) {
+		return false
+	}
+	m.size++
+	return true
+}
+
+/*
+O(k) where k is the number of values
+Assumes: SetMultimap m has been instantiated
+Associates every value with the key
+Returns the number of values that weren't associated with the key before
+*/
+func (m *SetMultimap[K, V]) PutAll(key K, values []V) int {
+	added := 0
+	for _, value := range values {
+		if m.Put(key, value) {
+			added++
+		}
+	}
+	return added
+}
+
+/*
+O(1)
+Assumes: SetMultimap m has been instantiated
+Returns a live read-only view of the values of the key
+The view is empty while the key has no values
+*/
+func (m *SetMultimap[K, V]) Get(key K) SetView[K, V] {
+	return SetView[K, V]{owner: m, key: key}
+}
+
+/*
+O(1)
+Assumes: SetMultimap m has been instantiated
+Removes the value from the values of the key
+Returns true if the value was there
+*/
+func (m *SetMultimap[K, V]) RemoveValue(key K, value V) bool {
+	values, exists := m.items[key]
+	if !exists || !values.Remove(value) {
+		return false
+	}
+	m.size--
+	if values.Size() == 0 {
+		delete(m.items, key)
+	}
+	return true
+}
+
+/*
+O(1)
+Assumes: SetMultimap m has been instantiated
+Removes the key together with all of its values
+Returns the removed values, or nil if the key had no values
+*/
+func (m *SetMultimap[K, V]) RemoveAll(key K) *HashSet[V] {
+	values, exists := m.items[key]
+	if !exists {
+		return nil
+	}
+	delete(m.items, key)
+	m.size -= values.Size()
+	return values
+}
+
+/*
+O(1)
+Assumes: SetMultimap m has been instantiated
+Returns true if the key has at least one value
+*/
+func (m SetMultimap[K, V]) ContainsKey(key K) bool {
+	_, exists := m.items[key]
+	return exists
+}
+
+/*
+O(1)
+Assumes: SetMultimap m has been instantiated
+Returns true if the value is associated with the key
+*/
+func (m SetMultimap[K, V]) ContainsEntry(key K, value V) bool {
+	values, exists := m.items[key]
+	return exists && values.Contains(value)
+}
+
+/*
+O(n)
+Assumes: SetMultimap m has been instantiated
+Returns the keys that have at least one value, in no particular order
+*/
+func (m SetMultimap[K, V]) Keys() []K {
+	keys := make([]K, 0, len(m.items))
+	for k := range m.items {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+/*
+O(1)
+Assumes: SetMultimap m has been instantiated
+Returns the total number of key-value pairs
+*/
+func (m SetMultimap[K, V]) Size() int {
+	return m.size
+}
+
+/*
+O(1)
+Assumes: SetMultimap m has been instantiated
+Returns the number of distinct keys
+*/
+func (m SetMultimap[K, V]) KeyCount() int {
+	return len(m.items)
+}
+
+/*
+O(1)
+Assumes: SetMultimap m has been instantiated
+Removes every key and value
+*/
+func (m *SetMultimap[K, V]) Clear() {
+	m.items = make(map[K]*HashSet[V])
+	m.size = 0
+}
+
+/*
+O(n)
+Assumes: SetMultimap m has been instantiated
+Returns a new multimap where every value is associated with the keys it was associated with
+*/
+func (m SetMultimap[K, V]) Invert() *SetMultimap[V, K] {
+	inverse := NewSetMultimap[V, K]()
+	for k, v := range m.All() {
+		inverse.Put(v, k)
+	}
+	return inverse
+}
+
+/*
+O(1)
+Assumes: SetMultimap m has been instantiated
+Returns an iterator over every key-value pair in no particular order
+The multimap must not be modified during the iteration
+*/
+func (m SetMultimap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, values := range m.items {
+			for v := range values.All() {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+/*
+O(1)
+Returns the number of values of the key
+*/
+func (v SetView[K, V]) Size() int {
+	values, exists := v.owner.items[v.key]
+	if !exists {
+		return 0
+	}
+	return values.Size()
+}
+
+/*
+O(1)
+Returns true if the key has no values
+*/
+func (v SetView[K, V]) IsEmpty() bool {
+	return v.Size() == 0
+}
+
+/*
+O(1)
+Returns true if the value is associated with the key
+*/
+func (v SetView[K, V]) Contains(value V) bool {
+	return v.owner.ContainsEntry(v.key, value)
+}
+
+/*
+O(k) where k is the number of values of the key
+Returns a copy of the values of the key in no particular order
+*/
+func (v SetView[K, V]) ToSlice() []V {
+	values, exists := v.owner.items[v.key]
+	if !exists {
+		return []V{}
+	}
+	return values.ToSlice()
+}
+
+/*
+O(1)
+Returns an iterator over the values of the key in no particular order
+The multimap must not be modified during the iteration
+*/
+func (v SetView[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		values, exists := v.owner.items[v.key]
+		if !exists {
+			return
+		}
+		for value := range values.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package utils
+
+import (
+	"reflect"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestListMultimap_PutAndGet(t *testing.T) {
+	m := NewListMultimap[string, int]()
+	view := m.Get("a")
+	if !view.IsEmpty() {
+		t.Errorf("Expected an empty view for a missing key")
+	}
+
+	m.Put("a", 1)
+	m.PutAll("a", []int{2, 1})
+	m.Put("b", 3)
+	if m.PutAll("c", []int{}) != 0 || m.ContainsKey("c") {
+		t.Errorf("Expected PutAll without values to add nothing")
+	}
+
+	// The view was created before the values were added
+	expected := []int{1, 2, 1}
+	if !reflect.DeepEqual(view.ToSlice(), expected) {
+		t.Errorf("Expected %v, but got %v", expected, view.ToSlice())
+	}
+	if item, err := view.At(1); err != nil || item != 2 {
+		t.Errorf("Expected 2 at index 1, but got %d (err: %v)", item, err)
+	}
+	if _, err := view.At(3); err == nil {
+		t.Errorf("At should return an error when the index is out of range")
+	}
+	if m.Size() != 4 || m.KeyCount() != 2 {
+		t.Errorf("Expected size 4 and key count 2, but got %d and %d", m.Size(), m.KeyCount())
+	}
+	if !m.ContainsEntry("b", 3) || m.ContainsEntry("b", 1) || !view.Contains(2) {
+		t.Errorf("ContainsEntry returned the wrong result")
+	}
+}
+
+func TestListMultimap_Remove(t *testing.T) {
+	m := NewListMultimap[string, int]()
+	m.PutAll("a", []int{1, 2, 1})
+	m.PutAll("b", []int{3, 4})
+	view := m.Get("a")
+
+	if !m.RemoveValue("a", 1) || m.RemoveValue("a", 5) || m.RemoveValue("c", 1) {
+		t.Errorf("RemoveValue returned the wrong result")
+	}
+	// Only the first occurrence is removed
+	if !reflect.DeepEqual(view.ToSlice(), []int{2, 1}) {
+		t.Errorf("Expected [2 1], but got %v", view.ToSlice())
+	}
+
+	removed := m.RemoveAll("b")
+	if !reflect.DeepEqual(removed, []int{3, 4}) || m.ContainsKey("b") {
+		t.Errorf("Expected RemoveAll to remove [3 4], but got %v", removed)
+	}
+	if m.RemoveAll("b") != nil {
+		t.Errorf("Expected nil when removing a missing key")
+	}
+
+	m.RemoveValue("a", 2)
+	m.RemoveValue("a", 1)
+	if m.ContainsKey("a") || m.KeyCount() != 0 || m.Size() != 0 || !view.IsEmpty() {
+		t.Errorf("Expected the key to disappear with its last value")
+	}
+}
+
+func TestListMultimap_Invert(t *testing.T) {
+	m := NewListMultimap[string, int]()
+	m.PutAll("a", []int{1, 2, 2})
+	m.PutAll("b", []int{2})
+
+	inverse := m.Invert()
+	if inverse.Size() != m.Size() || inverse.KeyCount() != 2 {
+		t.Errorf("Expected size 4 and key count 2, but got %d and %d", inverse.Size(), inverse.KeyCount())
+	}
+	twos := inverse.Get(2).ToSlice()
+	sort.Strings(twos)
+	if !reflect.DeepEqual(twos, []string{"a", "a", "b"}) {
+		t.Errorf("Expected [a a b], but got %v", twos)
+	}
+	if !reflect.DeepEqual(inverse.Get(1).ToSlice(), []string{"a"}) {
+		t.Errorf("Expected [a], but got %v", inverse.Get(1).ToSlice())
+	}
+}
+
+func TestSetMultimap_PutAndGet(t *testing.T) {
+	m := NewSetMultimap[string, int]()
+	view := m.Get("a")
+
+	if !m.Put("a", 1) || m.Put("a", 1) {
+		t.Errorf("Put returned the wrong result")
+	}
+	if added := m.PutAll("a", []int{1, 2, 3}); added != 2 {
+		t.Errorf("Expected PutAll to add 2 values, but got %d", added)
+	}
+	m.Put("b", 1)
+
+	if m.Size() != 4 || m.KeyCount() != 2 {
+		t.Errorf("Expected size 4 and key count 2, but got %d and %d", m.Size(), m.KeyCount())
+	}
+	if view.Size() != 3 || !view.Contains(3) || view.Contains(4) {
+		t.Errorf("Expected the view to hold [1 2 3], but got %v", view.ToSlice())
+	}
+	values := slices.Sorted(view.Values())
+	if !reflect.DeepEqual(values, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], but got %v", values)
+	}
+	if !m.ContainsEntry("b", 1) || m.ContainsEntry("b", 2) || m.ContainsEntry("c", 1) {
+		t.Errorf("ContainsEntry returned the wrong result")
+	}
+}
+
+func TestSetMultimap_Remove(t *testing.T) {
+	m := NewSetMultimap[string, int]()
+	m.PutAll("a", []int{1, 2})
+	m.PutAll("b", []int{3, 4})
+
+	if !m.RemoveValue("a", 1) || m.RemoveValue("a", 1) || m.RemoveValue("c", 1) {
+		t.Errorf("RemoveValue returned the wrong result")
+	}
+	removed := m.RemoveAll("b")
+	if removed == nil || !SetsEqual[int](removed, hashSetOf(3, 4)) {
+		t.Errorf("Expected RemoveAll to remove {3, 4}")
+	}
+	if m.RemoveAll("b") != nil {
+		t.Errorf("Expected nil when removing a missing key")
+	}
+	m.RemoveValue("a", 2)
+	if m.KeyCount() != 0 || m.Size() != 0 || !m.Get("a").IsEmpty() {
+		t.Errorf("Expected the key to disappear with its last value")
+	}
+}
+
+func TestSetMultimap_Invert(t *testing.T) {
+	m := NewSetMultimap[string, int]()
+	m.PutAll("a", []int{1, 2})
+	m.PutAll("b", []int{2, 3})
+
+	inverse := m.Invert()
+	if inverse.Size() != 4 || inverse.KeyCount() != 3 {
+		t.Errorf("Expected size 4 and key count 3, but got %d and %d", inverse.Size(), inverse.KeyCount())
+	}
+	twos := inverse.Get(2).ToSlice()
+	sort.Strings(twos)
+	if !reflect.DeepEqual(twos, []string{"a", "b"}) {
+		t.Errorf("Expected [a b], but got %v", twos)
+	}
+
+	entries := 0
+	for k, v := range m.All() {
+		if !inverse.ContainsEntry(v, k) {
+			t.Errorf("Expected the inverse to contain (%d, %s)", v, k)
+		}
+		entries++
+	}
+	if entries != m.Size() {
+		t.Errorf("Expected All to yield %d entries, but got %d", m.Size(), entries)
+	}
+}
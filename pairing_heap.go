@@ -0,0 +1,136 @@
+package utils
+
+/*
+A pairing heap backend for PriorityQueue, selected with the PairingHeap strategy
+Every node keeps its children as a linked list through child and sibling.
+Insertion links a single node with the root, and removing the root melds its children with the two-pass method
+*/
+type pairingHeap[T interface{}] struct {
+	comparator func(T, T) int
+	root       *pairingNode[T]
+	n          int
+}
+
+type pairingNode[T interface{}] struct {
+	item    T
+	child   *pairingNode[T]
+	sibling *pairingNode[T]
+}
+
+func newPairingHeap[T interface{}](comp func(T, T) int) *pairingHeap[T] {
+	return &pairingHeap[T]{comparator: comp}
+}
+
+// O(1)
+func (h *pairingHeap[T]) push(item T) {
+	h.root = h.link(h.root, &pairingNode[T]{item: item})
+	h.n++
+}
+
+// amortized O(log n)
+func (h *pairingHeap[T]) pop() T {
+	top := h.root
+	h.root = h.mergePairs(top.child)
+	h.n--
+	return top.item
+}
+
+// O(1)
+func (h *pairingHeap[T]) peek() T {
+	return h.root.item
+}
+
+func (h *pairingHeap[T]) size() int {
+	return h.n
+}
+
+func (h *pairingHeap[T]) clear() {
+	h.root = nil
+	h.n = 0
+}
+
+// O(n)
+func (h *pairingHeap[T]) items() []T {
+	out := make([]T, 0, h.n)
+	stack := make([]*pairingNode[T], 0)
+	if h.root != nil {
+		stack = append(stack, h.root)
+	}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		out = append(out, node.item)
+		for child := node.child; child != nil; child = child.sibling {
+			stack = append(stack, child)
+		}
+	}
+	return out
+}
+
+// O(n), the copy is a single level heap that restructures itself as it is popped
+func (h *pairingHeap[T]) clone() nodeHeap[T] {
+	out := newPairingHeap(h.comparator)
+	for _, item := range h.items() {
+		out.push(item)
+	}
+	return out
+}
+
+// O(1)
+func (h *pairingHeap[T]) meld(other nodeHeap[T]) bool {
+	o, ok := other.(*pairingHeap[T])
+	if !ok {
+		return false
+	}
+	h.root = h.link(h.root, o.root)
+	h.n += o.n
+	o.clear()
+	return true
+}
+
+// PRIVATE HELPER FUNCTIONS BELOW
+
+// makes the root that comes last the first child of the other, assumes both have no siblings
+func (h *pairingHeap[T]) link(a, b *pairingNode[T]) *pairingNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.comparator(b.item, a.item) < 0 {
+		a, b = b, a
+	}
+	b.sibling = a.child
+	a.child = b
+	return a
+}
+
+// links the siblings in pairs from left to right and then melds the pairs from right to left
+func (h *pairingHeap[T]) mergePairs(first *pairingNode[T]) *pairingNode[T] {
+	// the linked pairs are collected in reverse order through their sibling pointers
+	var pairs *pairingNode[T]
+	for first != nil {
+		a := first
+		b := a.sibling
+		if b == nil {
+			a.sibling = pairs
+			pairs = a
+			break
+		}
+		first = b.sibling
+		a.sibling = nil
+		b.sibling = nil
+		pair := h.link(a, b)
+		pair.sibling = pairs
+		pairs = pair
+	}
+	var root *pairingNode[T]
+	for pairs != nil {
+		next := pairs.sibling
+		pairs.sibling = nil
+		root = h.link(root, pairs)
+		pairs = next
+	}
+	return root
+}
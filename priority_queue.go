@@ -1,30 +1,176 @@
 package utils
 
 import (
-	"errors"
+	"iter"
+	"math/bits"
 )
 
 type PriorityQueue[T interface{}] struct {
 	comparator func(T, T) int
-	contents   []T
+	// shared by the queues that are known to use the same comparator, see NewOrdering
+	order *Ordering[T]
+	// the heap of the array based strategies, where every node has arity children
+	contents []T
+	arity    int
+	// the heap of the pointer based strategies, nil for the array based ones
+	nodes nodeHeap[T]
+}
+
+/*
+A comparator that priority queues can share
+Functions cannot be compared, so Meld can only link the heaps of two queues in O(1)
+when they are known to share their comparator, which is when they were built from the same Ordering
+*/
+type Ordering[T interface{}] struct {
+	comparator func(T, T) int
+}
+
+/*
+Selects the heap that backs a PriorityQueue
+Every strategy supports the whole PriorityQueue API, they only differ in performance
+*/
+type HeapStrategy struct {
+	kind  heapKind
+	arity int
+}
+
+type heapKind int
+
+const (
+	arrayHeap heapKind = iota
+	pairingHeapKind
+	fibonacciHeapKind
+)
+
+// a heap made of linked nodes, the methods that remove or return the top assume the heap is not empty
+type nodeHeap[T interface{}] interface {
+	push(item T)
+	pop() T
+	peek() T
+	size() int
+	clear()
+	// the items in no particular order
+	items() []T
+	clone() nodeHeap[T]
+	// moves every item of other into the heap in O(1), returns false if other is another kind of heap
+	meld(other nodeHeap[T]) bool
+}
+
+/*
+The default strategy: an implicit binary heap stored in a slice
+O(log n) Enqueue and Dequeue
+*/
+func BinaryHeap() HeapStrategy {
+	return DaryHeap(2)
+}
+
+/*
+An implicit heap stored in a slice where every node has d children
+A larger d gives a shallower tree and fewer cache misses: Enqueue is O(log_d n) and Dequeue O(d log_d n)
+A d smaller than 2 is treated as 2
+*/
+func DaryHeap(d int) HeapStrategy {
+	return HeapStrategy{kind: arrayHeap, arity: max(d, 2)}
+}
+
+/*
+A pairing heap made of linked nodes
+O(1) Enqueue and amortized O(log n) Dequeue, and usually the fastest pointer based heap in practice
+*/
+func PairingHeap() HeapStrategy {
+	return HeapStrategy{kind: pairingHeapKind}
+}
+
+/*
+A Fibonacci heap made of linked nodes
+O(1) Enqueue and amortized O(log n) Dequeue with a larger constant factor than the pairing heap
+Its strength is decrease-key, which IndexedPriorityQueue.Update uses when the queue is built with this strategy.
+PriorityQueue has no decrease-key, so there the pairing heap is usually faster
+*/
+func FibonacciHeap() HeapStrategy {
+	return HeapStrategy{kind: fibonacciHeapKind}
 }
 
 func NewPriorityQueue[T interface{}](comp func(T, T) int) *PriorityQueue[T] {
-	return &PriorityQueue[T]{
-		comparator: comp,
-		contents:   make([]T, 0)}
+	return NewPriorityQueueWithStrategy(comp, BinaryHeap())
+}
+
+/*
+O(1)
+Instantiates a new empty PriorityQueue backed by the heap the strategy selects
+The queue gets an Ordering of its own, so Meld with a queue built by another call is never O(1),
+even with the same comparator. Build queues that are melded with NewPriorityQueueWithOrdering or Sibling instead
+*/
+func NewPriorityQueueWithStrategy[T interface{}](comp func(T, T) int, strategy HeapStrategy) *PriorityQueue[T] {
+	return NewPriorityQueueWithOrdering(NewOrdering(comp), strategy)
+}
+
+/*
+O(1)
+Instantiates a new Ordering that sorts by the comparator
+*/
+func NewOrdering[T interface{}](comp func(T, T) int) *Ordering[T] {
+	return &Ordering[T]{comparator: comp}
+}
+
+/*
+O(1)
+Instantiates a new empty PriorityQueue that sorts by the ordering and is backed by the heap the strategy selects
+Queues built from the same ordering can be melded in O(1) when they use the pairing or the Fibonacci heap,
+for example shards that are filled separately and melded at the end
+*/
+func NewPriorityQueueWithOrdering[T interface{}](order *Ordering[T], strategy HeapStrategy) *PriorityQueue[T] {
+	q := &PriorityQueue[T]{
+		comparator: order.comparator,
+		order:      order,
+		contents:   make([]T, 0),
+		arity:      max(strategy.arity, 2)}
+	switch strategy.kind {
+	case pairingHeapKind:
+		q.nodes = newPairingHeap(order.comparator)
+	case fibonacciHeapKind:
+		q.nodes = newFibonacciHeap(order.comparator)
+	}
+	return q
+}
+
+/*
+O(1)
+Assumes: the priority queue has been instantiated
+Instantiates a new empty PriorityQueue with the same ordering and strategy as q
+*/
+func (q PriorityQueue[T]) Sibling() *PriorityQueue[T] {
+	return NewPriorityQueueWithOrdering(q.order, q.strategy())
+}
+
+/*
+O(n)
+Instantiates a new PriorityQueue holding the supplied items
+The heap is built bottom-up, which is faster than enqueueing the items one by one
+The slice is copied, so the caller may keep using it
+*/
+func NewPriorityQueueFrom[T interface{}](items []T, comp func(T, T) int) *PriorityQueue[T] {
+	q := NewPriorityQueue(comp)
+	q.contents = make([]T, len(items))
+	copy(q.contents, items)
+	q.heapify()
+	return q
 }
 
 /*
 O(log n)
 Assumes: PriorityQueue has been initiated
-Inserts the item into a binary heap
+Inserts the item into the heap
 */
 func (q *PriorityQueue[T]) Enqueue(item T) {
+	if q.nodes != nil {
+		q.nodes.push(item)
+		return
+	}
 	pos := len(q.contents)
 	q.contents = append(q.contents, item)
 	done := false
-	parentIndex := getParentIndex(pos)
+	parentIndex := q.parentOf(pos)
 	for parentIndex >= 0 && !done {
 		if q.comparator(q.contents[parentIndex], q.contents[pos]) > 0 {
 			parent := q.contents[parentIndex]
@@ -32,7 +178,7 @@ func (q *PriorityQueue[T]) Enqueue(item T) {
 			q.contents[parentIndex] = child
 			q.contents[pos] = parent
 			pos = parentIndex
-			parentIndex = getParentIndex(pos)
+			parentIndex = q.parentOf(pos)
 		} else {
 			done = true
 		}
@@ -40,11 +186,18 @@ func (q *PriorityQueue[T]) Enqueue(item T) {
 }
 
 /*
-O(n log n)
-Assumes: the priority queue has bee instantiated
+O(min(m log(n + m), n + m)) where m is the number of items
+Assumes: the priority queue has been instantiated
 Enqueues all items
+Rebuilds the whole heap bottom-up when that is cheaper than enqueueing the items one by one
 */
 func (q *PriorityQueue[T]) EnqueueAll(items []T) {
+	n := len(q.contents) + len(items)
+	if q.nodes == nil && len(items)*bits.Len(uint(n)) > n {
+		q.contents = append(q.contents, items...)
+		q.heapify()
+		return
+	}
 	for _, item := range items {
 		q.Enqueue(item)
 	}
@@ -57,30 +210,39 @@ Removes the top element and restores the heap invariant
 */
 func (q *PriorityQueue[T]) Dequeue() (T, error) {
 	var nilVal T
-	if len(q.contents) == 0 {
-		return nilVal, errors.New(dequeueErrorMsg)
+	if q.IsEmpty() {
+		return nilVal, &OpError{Op: "PriorityQueue.Dequeue", Err: ErrEmpty}
+	}
+	if q.nodes != nil {
+		return q.nodes.pop(), nil
 	}
 	item := q.contents[0]
 	n := len(q.contents)
-	pos := 0
-	q.contents[pos] = q.contents[n-1]
+	q.contents[0] = q.contents[n-1]
 	q.contents = q.contents[:n-1]
-	done := false
-	for !done {
-		swap := q.getChildSwapIndex(pos)
-		if swap < 0 {
-			done = true
-		} else {
-			parent := q.contents[pos]
-			child := q.contents[swap]
-			q.contents[swap] = parent
-			q.contents[pos] = child
-			pos = swap
-		}
-	}
+	q.siftDown(0)
 	return item, nil
 }
 
+/*
+O(1) for two pairing or two Fibonacci heaps built from the same Ordering, otherwise O(min(m log(n + m), n + m)) where m is the size of other
+Assumes: the priority queue q has been instantiated
+Moves every item of other into q, leaving other empty, and the items are ordered by the comparator of q afterwards.
+Queues built from different orderings, which includes any two queues from NewPriorityQueueWithStrategy,
+are enqueued item by item since their heaps may be ordered differently
+No-op if other is nil or q itself
+*/
+func (q *PriorityQueue[T]) Meld(other *PriorityQueue[T]) {
+	if other == nil || q == other {
+		return
+	}
+	if q.order != nil && q.order == other.order && q.nodes != nil && other.nodes != nil && q.nodes.meld(other.nodes) {
+		return
+	}
+	q.EnqueueAll(other.ToSlice())
+	other.Clear()
+}
+
 /*
 O(n log n)
 Assumes: priority queue has been instantiated
@@ -88,7 +250,7 @@ Dequeues all items and returns them as a slice. The items will be sorted by the
 */
 func (q *PriorityQueue[T]) DequeueAll() []T {
 	out := make([]T, 0)
-	for len(q.contents) > 0 {
+	for !q.IsEmpty() {
 		item, _ := q.Dequeue()
 		out = append(out, item)
 	}
@@ -102,8 +264,11 @@ Returns the top element without removing it from the queue
 */
 func (q PriorityQueue[T]) Peek() (T, error) {
 	var nilVal T
-	if len(q.contents) == 0 {
-		return nilVal, errors.New(peekErrorMsg)
+	if q.IsEmpty() {
+		return nilVal, &OpError{Op: "PriorityQueue.Peek", Err: ErrEmpty}
+	}
+	if q.nodes != nil {
+		return q.nodes.peek(), nil
 	}
 	return q.contents[0], nil
 }
@@ -114,7 +279,7 @@ Assumes: the priority queue has been instantiated
 Returns true if the queue is empty
 */
 func (q PriorityQueue[T]) IsEmpty() bool {
-	return len(q.contents) == 0
+	return q.Size() == 0
 }
 
 /*
@@ -123,14 +288,21 @@ Wipes contents of the queue
 */
 func (q *PriorityQueue[T]) Clear() {
 	q.contents = make([]T, 0)
+	if q.nodes != nil {
+		q.nodes.clear()
+	}
 }
 
 /*
 O(n)
 Assumes: the priority queue has been instantiated
 Returns the inner representation of the queue as a slice
+The pointer based heaps return their items in no particular order
 */
 func (q PriorityQueue[T]) ToSlice() []T {
+	if q.nodes != nil {
+		return q.nodes.items()
+	}
 	out := make([]T, 0)
 	for _, val := range q.contents {
 		out = append(out, val)
@@ -144,52 +316,159 @@ Assumes: the priority queue has been instantiated
 Returns the number of items in the queue
 */
 func (q PriorityQueue[T]) Size() int {
+	if q.nodes != nil {
+		return q.nodes.size()
+	}
 	return len(q.contents)
 }
 
-// PRIVATE HELPER FUNCTIONS BELOW
+/*
+O(n + k log n) for the first k items
+Assumes: the priority queue has been instantiated
+Returns an iterator over the ranks and items in the order they would be dequeued
+The queue itself is left untouched, the iterator drains a copy of the heap as it goes
+*/
+func (q PriorityQueue[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		heap := q.clone()
+		for i := 0; !heap.IsEmpty(); i++ {
+			item, _ := heap.Dequeue()
+			if !yield(i, item) {
+				return
+			}
+		}
+	}
+}
 
-// index or -1 if no swap
-func (q *PriorityQueue[T]) getChildSwapIndex(parentIndex int) int {
-	right := getRightChild(parentIndex)
-	left := getLeftChild(parentIndex)
-	// no children
-	if left > len(q.contents)-1 {
-		return -1
-		// 1 child
-	} else if right > len(q.contents)-1 {
-		if q.comparator(q.contents[parentIndex], q.contents[left]) > 0 {
-			return left
-		} else {
-			return -1
+/*
+O(n + k log n) for the first k items
+Assumes: the priority queue has been instantiated
+Returns an iterator over the items in the order they would be dequeued, without changing the queue
+*/
+func (q PriorityQueue[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range q.All() {
+			if !yield(item) {
+				return
+			}
 		}
-		// 2 larger children
-	} else if !(q.comparator(q.contents[parentIndex], q.contents[left]) > 0 || q.comparator(q.contents[parentIndex], q.contents[right]) > 0) {
-		return -1
-		// at least 1 smaller child: is it left?
-	} else if q.comparator(q.contents[parentIndex], q.contents[left]) > 0 {
-		// left is larger?
-		if q.comparator(q.contents[left], q.contents[right]) > 0 {
-			// then right is the smallest
-			return right
-		} else {
-			return left
+	}
+}
+
+/*
+O(n log n)
+Assumes: the priority queue has been instantiated
+Returns an iterator over the ranks and items in the reverse of the order they would be dequeued
+The queue itself is left untouched
+*/
+func (q PriorityQueue[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		sorted := q.clone().DequeueAll()
+		for i := len(sorted) - 1; i >= 0; i-- {
+			if !yield(i, sorted[i]) {
+				return
+			}
+		}
+	}
+}
+
+/*
+O(k log n) for the first k items
+Assumes: the priority queue has been instantiated
+Returns an iterator that dequeues the items one at a time
+Items that are not reached because the loop stops early stay in the queue
+*/
+func (q *PriorityQueue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for !q.IsEmpty() {
+			item, _ := q.Dequeue()
+			if !yield(item) {
+				return
+			}
 		}
-		// right must be smaller than parent and the smallest child
-	} else {
-		return right
 	}
+}
+
+/*
+O(n)
+Instantiates a new PriorityQueue holding the items of the iterator
+*/
+func CollectPriorityQueue[T interface{}](seq iter.Seq[T], comp func(T, T) int) *PriorityQueue[T] {
+	q := NewPriorityQueue(comp)
+	for item := range seq {
+		q.contents = append(q.contents, item)
+	}
+	q.heapify()
+	return q
+}
 
+// PRIVATE HELPER FUNCTIONS BELOW
+
+func (q PriorityQueue[T]) strategy() HeapStrategy {
+	switch q.nodes.(type) {
+	case *pairingHeap[T]:
+		return PairingHeap()
+	case *fibonacciHeap[T]:
+		return FibonacciHeap()
+	}
+	return DaryHeap(q.arity)
+}
+
+func (q PriorityQueue[T]) clone() *PriorityQueue[T] {
+	contents := make([]T, len(q.contents))
+	copy(contents, q.contents)
+	out := &PriorityQueue[T]{comparator: q.comparator, order: q.order, contents: contents, arity: q.arity}
+	if q.nodes != nil {
+		out.nodes = q.nodes.clone()
+	}
+	return out
+}
+
+// moves the item at pos down until the heap invariant holds below it
+func (q *PriorityQueue[T]) siftDown(pos int) {
+	done := false
+	for !done {
+		swap := q.getChildSwapIndex(pos)
+		if swap < 0 {
+			done = true
+		} else {
+			parent := q.contents[pos]
+			child := q.contents[swap]
+			q.contents[swap] = parent
+			q.contents[pos] = child
+			pos = swap
+		}
+	}
 }
 
-func getLeftChild(i int) int {
-	return 2*i + 1
+// bottom-up heap construction, O(n)
+func (q *PriorityQueue[T]) heapify() {
+	for pos := q.parentOf(len(q.contents) - 1); pos >= 0; pos-- {
+		q.siftDown(pos)
+	}
 }
 
-func getRightChild(i int) int {
-	return 2*i + 2
+// index of the child that comes first if it comes before the parent, or -1 if no swap
+func (q *PriorityQueue[T]) getChildSwapIndex(parentIndex int) int {
+	first := q.arity*parentIndex + 1
+	if first > len(q.contents)-1 {
+		return -1
+	}
+	best := first
+	for child := first + 1; child < min(first+q.arity, len(q.contents)); child++ {
+		if q.comparator(q.contents[best], q.contents[child]) > 0 {
+			best = child
+		}
+	}
+	if q.comparator(q.contents[parentIndex], q.contents[best]) > 0 {
+		return best
+	}
+	return -1
 }
 
-func getParentIndex(i int) int {
-	return (i - 1) / 2
+func (q *PriorityQueue[T]) parentOf(i int) int {
+	if i <= 0 {
+		return -1
+	}
+	return (i - 1) / q.arity
 }
@@ -3,6 +3,7 @@ package utils
 import (
 	"errors"
 	"math/rand"
+	"slices"
 	"testing"
 )
 
@@ -102,6 +103,95 @@ func TestDequeue2(t *testing.T) {
 
 }
 
+func TestNewPriorityQueueFrom(t *testing.T) {
+	items := make([]int, 1000)
+	for i := range items {
+		items[i] = rand.Intn(100)
+	}
+	pq := NewPriorityQueueFrom(items, cmp)
+
+	err, is := pqInvariant[int](*pq)
+	if err != nil {
+		t.Errorf("%s. Parent: %d | Left: %d | Right: %d", err.Error(), pq.contents[is[0]], pq.contents[is[1]], pq.contents[is[2]])
+	}
+
+	// The queue must not share memory with the argument
+	items[0] = -1
+	for _, v := range pq.contents {
+		if v == -1 {
+			t.Errorf("Expected the queue to copy the supplied slice")
+		}
+	}
+
+	previous := -1
+	for !pq.IsEmpty() {
+		item, _ := pq.Dequeue()
+		if item < previous {
+			t.Errorf("Dequeued %d after %d", item, previous)
+		}
+		previous = item
+	}
+}
+
+func TestEnqueueAll(t *testing.T) {
+	// Small batches are enqueued one by one, large batches rebuild the heap
+	for _, batch := range []int{1, 10, 1000} {
+		pq := NewPriorityQueue[int](cmp)
+		for i := 0; i < 100; i++ {
+			pq.Enqueue(rand.Int())
+		}
+		items := make([]int, batch)
+		for i := range items {
+			items[i] = rand.Int()
+		}
+		pq.EnqueueAll(items)
+
+		if pq.Size() != 100+batch {
+			t.Errorf("Expected size %d, but got %d", 100+batch, pq.Size())
+		}
+		err, is := pqInvariant[int](*pq)
+		if err != nil {
+			t.Errorf("%s. Parent: %d | Left: %d | Right: %d", err.Error(), pq.contents[is[0]], pq.contents[is[1]], pq.contents[is[2]])
+		}
+	}
+}
+
+func benchmarkItems(n int) []int {
+	items := make([]int, n)
+	for i := range items {
+		items[i] = rand.Int()
+	}
+	return items
+}
+
+func BenchmarkEnqueueOneByOne(b *testing.B) {
+	items := benchmarkItems(1_000_000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pq := NewPriorityQueue[int](cmp)
+		for _, item := range items {
+			pq.Enqueue(item)
+		}
+	}
+}
+
+func BenchmarkEnqueueAll(b *testing.B) {
+	items := benchmarkItems(1_000_000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pq := NewPriorityQueue[int](cmp)
+		pq.EnqueueAll(items)
+	}
+}
+
+func BenchmarkNewPriorityQueueFrom(b *testing.B) {
+	items := benchmarkItems(1_000_000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewPriorityQueueFrom(items, cmp)
+	}
+}
+
 func pqInvariant[T interface{}](pq PriorityQueue[T]) (error, []int) {
 	for i := 0; i < (len(pq.contents)-1)/2; i++ {
 		if !(pq.comparator(pq.contents[i], pq.contents[2*i+1]) <= 0 && pq.comparator(pq.contents[i], pq.contents[2*i+2]) <= 0) {
@@ -110,3 +200,304 @@ func pqInvariant[T interface{}](pq PriorityQueue[T]) (error, []int) {
 	}
 	return nil, []int{}
 }
+
+func TestPriorityQueue_Iterators(t *testing.T) {
+	pq := CollectPriorityQueue(slices.Values([]int{5, 1, 4, 2, 3}), cmp)
+
+	ordered := make([]int, 0)
+	for rank, item := range pq.All() {
+		if rank != len(ordered) {
+			t.Errorf("Expected rank %d, but got %d", len(ordered), rank)
+		}
+		ordered = append(ordered, item)
+	}
+	if !slices.Equal(ordered, []int{1, 2, 3, 4, 5}) || !slices.Equal(slices.Collect(pq.Values()), ordered) {
+		t.Errorf("Expected All and Values in priority order, but got %v", ordered)
+	}
+
+	backward := make([]int, 0)
+	for rank, item := range pq.Backward() {
+		if item != rank+1 {
+			t.Errorf("Expected item %d at rank %d, but got %d", rank+1, rank, item)
+		}
+		backward = append(backward, item)
+	}
+	if !slices.Equal(backward, []int{5, 4, 3, 2, 1}) {
+		t.Errorf("Expected Backward in reverse priority order, but got %v", backward)
+	}
+	if pq.Size() != 5 {
+		t.Errorf("Expected the non-destructive iterators to keep size 5, but got %d", pq.Size())
+	}
+
+	drained := make([]int, 0)
+	for item := range pq.Drain() {
+		drained = append(drained, item)
+		if item == 2 {
+			break
+		}
+	}
+	if !slices.Equal(drained, []int{1, 2}) || pq.Size() != 3 {
+		t.Errorf("Expected Drain to dequeue [1 2] and leave 3 items, but got %v and %d", drained, pq.Size())
+	}
+}
+
+var heapStrategies = map[string]HeapStrategy{
+	"Binary":    BinaryHeap(),
+	"4-ary":     DaryHeap(4),
+	"8-ary":     DaryHeap(8),
+	"Pairing":   PairingHeap(),
+	"Fibonacci": FibonacciHeap(),
+}
+
+func TestPriorityQueue_Strategies(t *testing.T) {
+	for name, strategy := range heapStrategies {
+		t.Run(name, func(t *testing.T) {
+			pq := NewPriorityQueueWithStrategy(cmp, strategy)
+			reference := make([]int, 0)
+			for i := 0; i < 5000; i++ {
+				if rand.Intn(3) == 0 {
+					item, err := pq.Dequeue()
+					if len(reference) == 0 {
+						if err == nil {
+							t.Fatalf("Dequeue should return an error when the queue is empty")
+						}
+						continue
+					}
+					if err != nil || item != reference[0] {
+						t.Fatalf("Expected %d, but got %d (err: %v)", reference[0], item, err)
+					}
+					reference = reference[1:]
+				} else {
+					item := rand.Intn(1000)
+					pq.Enqueue(item)
+					i, _ := slices.BinarySearch(reference, item)
+					reference = slices.Insert(reference, i, item)
+				}
+				if pq.Size() != len(reference) {
+					t.Fatalf("Expected size %d, but got %d", len(reference), pq.Size())
+				}
+			}
+
+			pq.EnqueueAll(benchmarkItems(1000))
+			contents := pq.ToSlice()
+			slices.Sort(contents)
+			if !slices.Equal(slices.Collect(pq.Values()), contents) {
+				t.Errorf("Expected Values to yield the contents in priority order")
+			}
+			if top, err := pq.Peek(); err != nil || top != contents[0] {
+				t.Errorf("Expected top %d, but got %d (err: %v)", contents[0], top, err)
+			}
+			if sorted := pq.DequeueAll(); !slices.Equal(sorted, contents) {
+				t.Errorf("Expected DequeueAll to return the contents in priority order")
+			}
+
+			pq.EnqueueAll([]int{3, 1, 2})
+			pq.Clear()
+			if !pq.IsEmpty() || len(pq.ToSlice()) != 0 {
+				t.Errorf("Expected an empty queue after Clear")
+			}
+			if _, err := pq.Peek(); err == nil {
+				t.Errorf("Peek should return an error when the queue is empty")
+			}
+		})
+	}
+}
+
+func TestPriorityQueue_DaryHeapInvariant(t *testing.T) {
+	pq := NewPriorityQueueWithStrategy(cmp2, DaryHeap(5))
+	pq.EnqueueAll(benchmarkItems(10))
+	pq.EnqueueAll(benchmarkItems(1000))
+	for i := 0; i < 100; i++ {
+		pq.Dequeue()
+	}
+	for i := 1; i < len(pq.contents); i++ {
+		parent := (i - 1) / 5
+		if cmp2(pq.contents[parent], pq.contents[i]) > 0 {
+			t.Fatalf("Heap invariant broken between %d and %d", parent, i)
+		}
+	}
+	if DaryHeap(1) != BinaryHeap() {
+		t.Errorf("Expected an arity below 2 to be treated as 2")
+	}
+}
+
+func BenchmarkPriorityQueue_Strategies(b *testing.B) {
+	items := benchmarkItems(100_000)
+	for _, name := range []string{"Binary", "4-ary", "8-ary", "Pairing", "Fibonacci"} {
+		strategy := heapStrategies[name]
+		b.Run(name+"/EnqueueThenDrain", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				pq := NewPriorityQueueWithStrategy(cmp, strategy)
+				for _, item := range items {
+					pq.Enqueue(item)
+				}
+				for !pq.IsEmpty() {
+					pq.Dequeue()
+				}
+			}
+		})
+		b.Run(name+"/Interleaved", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				pq := NewPriorityQueueWithStrategy(cmp, strategy)
+				for j, item := range items {
+					pq.Enqueue(item)
+					if j%2 == 1 {
+						pq.Dequeue()
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPriorityQueue_Meld(t *testing.T) {
+	for intoName, into := range heapStrategies {
+		for fromName, from := range heapStrategies {
+			t.Run(intoName+"<-"+fromName, func(t *testing.T) {
+				a := NewPriorityQueueWithStrategy(cmp, into)
+				b := NewPriorityQueueWithStrategy(cmp, from)
+				a.EnqueueAll(benchmarkItems(100))
+				b.EnqueueAll(benchmarkItems(50))
+				expected := append(a.ToSlice(), b.ToSlice()...)
+				slices.Sort(expected)
+
+				a.Meld(b)
+				if !b.IsEmpty() {
+					t.Errorf("Expected the other queue to be empty after Meld, but got size %d", b.Size())
+				}
+				if sorted := a.DequeueAll(); !slices.Equal(sorted, expected) {
+					t.Errorf("Expected the melded queue to hold every item in priority order")
+				}
+				// Both queues stay usable
+				b.Enqueue(1)
+				if a.Meld(b); a.Size() != 1 || b.Size() != 0 {
+					t.Errorf("Expected melding into an empty queue to move the item")
+				}
+			})
+		}
+	}
+}
+
+func TestPriorityQueue_MeldSiblings(t *testing.T) {
+	for name, strategy := range heapStrategies {
+		a := NewPriorityQueueWithStrategy(cmp, strategy)
+		b := a.Sibling()
+		c := b.Sibling()
+		a.EnqueueAll([]int{5, 1, 9})
+		b.EnqueueAll([]int{4, 8})
+		c.EnqueueAll([]int{7, 2})
+
+		b.Meld(c)
+		a.Meld(b)
+		if !b.IsEmpty() || !c.IsEmpty() {
+			t.Errorf("%s: expected the siblings to be empty after Meld", name)
+		}
+		if sorted := a.DequeueAll(); !slices.Equal(sorted, []int{1, 2, 4, 5, 7, 8, 9}) {
+			t.Errorf("%s: expected [1 2 4 5 7 8 9], but got %v", name, sorted)
+		}
+	}
+}
+
+func TestPriorityQueue_MeldShards(t *testing.T) {
+	for _, name := range []string{"Pairing", "Fibonacci"} {
+		// Count the comparisons to tell the O(1) link from enqueueing every item
+		comparisons := 0
+		order := NewOrdering(func(a, b int) int {
+			comparisons++
+			return cmp(a, b)
+		})
+		expected := make([]int, 0)
+		shards := make([]*PriorityQueue[int], 4)
+		for i := range shards {
+			items := benchmarkItems(100)
+			expected = append(expected, items...)
+			shards[i] = NewPriorityQueueWithOrdering(order, heapStrategies[name])
+			shards[i].EnqueueAll(items)
+		}
+
+		merged := NewPriorityQueueWithOrdering(order, heapStrategies[name])
+		for _, shard := range shards {
+			comparisons = 0
+			merged.Meld(shard)
+			if comparisons > 1 {
+				t.Errorf("%s: expected Meld of shards built from the same ordering to link the heaps, but it compared %d times", name, comparisons)
+			}
+			if !shard.IsEmpty() {
+				t.Errorf("%s: expected the shard to be empty after Meld", name)
+			}
+		}
+		slices.Sort(expected)
+		if sorted := merged.DequeueAll(); !slices.Equal(sorted, expected) {
+			t.Errorf("%s: expected the merged queue to hold every item in priority order", name)
+		}
+
+		// Queues built separately from the same comparator do not share an ordering, so their items are enqueued again
+		a := NewPriorityQueueWithStrategy(cmp, heapStrategies[name])
+		b := NewPriorityQueueWithStrategy(cmp, heapStrategies[name])
+		a.EnqueueAll([]int{3, 1})
+		b.EnqueueAll([]int{4, 2})
+		a.Meld(b)
+		if sorted := a.DequeueAll(); !slices.Equal(sorted, []int{1, 2, 3, 4}) || !b.IsEmpty() {
+			t.Errorf("%s: expected [1 2 3 4] and an empty queue, but got %v and size %d", name, sorted, b.Size())
+		}
+	}
+}
+
+func TestPriorityQueue_MeldComparatorMismatch(t *testing.T) {
+	a := NewPriorityQueueWithStrategy(cmp, PairingHeap())
+	b := NewPriorityQueueWithStrategy(cmp2, PairingHeap())
+	a.EnqueueAll([]int{1, 2})
+	b.EnqueueAll([]int{3, 4})
+
+	// The items of b are reordered by the comparator of a
+	a.Meld(b)
+	if sorted := a.DequeueAll(); !slices.Equal(sorted, []int{1, 2, 3, 4}) || !b.IsEmpty() {
+		t.Errorf("Expected [1 2 3 4] and an empty queue, but got %v and size %d", sorted, b.Size())
+	}
+
+	// Closures made by the same function literal share their code but not their ordering
+	factory := func(descending bool) func(int, int) int {
+		return func(a, b int) int {
+			if descending {
+				return b - a
+			}
+			return a - b
+		}
+	}
+	for name, strategy := range heapStrategies {
+		ascending := NewPriorityQueueWithStrategy(factory(false), strategy)
+		descending := NewPriorityQueueWithStrategy(factory(true), strategy)
+		ascending.EnqueueAll([]int{5, 6})
+		descending.EnqueueAll([]int{1, 2, 3})
+		ascending.Meld(descending)
+		if sorted := ascending.DequeueAll(); !slices.Equal(sorted, []int{1, 2, 3, 5, 6}) {
+			t.Errorf("%s: expected [1 2 3 5 6], but got %v", name, sorted)
+		}
+	}
+
+	a.Enqueue(1)
+	if a.Meld(a); a.Size() != 1 {
+		t.Errorf("Expected melding a queue with itself to be a no-op")
+	}
+	if a.Meld(nil); a.Size() != 1 {
+		t.Errorf("Expected melding nil to be a no-op")
+	}
+}
+
+func BenchmarkPriorityQueue_Meld(b *testing.B) {
+	items := benchmarkItems(100_000)
+	for _, name := range []string{"Binary", "Pairing", "Fibonacci"} {
+		strategy := heapStrategies[name]
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				x := NewPriorityQueueWithStrategy(cmp, strategy)
+				y := x.Sibling()
+				x.EnqueueAll(items)
+				y.EnqueueAll(items)
+				b.StartTimer()
+				x.Meld(y)
+			}
+		})
+	}
+}
@@ -1,9 +1,6 @@
 package utils
 
-import "errors"
-
-const dequeueErrorMsg string = "Cannot dequeue an empty queue"
-const peekErrorMsg string = "Cannot peek in an empty queue"
+import "iter"
 
 /*
  A generic queue interface
@@ -22,9 +19,12 @@ type Queue[T interface{}] interface {
 
 /*
  A FifoQueue
+ Backed by a growable circular buffer, so dequeued slots are reused and
+ the memory is released again when the queue drains
+ The zero value is an empty queue ready to use
 */
 type FifoQueue[T interface{}] struct {
-	contents []T
+	contents ring[T]
 }
 
 /*
@@ -32,16 +32,25 @@ O(1)
 Instantiates a new FifoQueue
 */
 func NewFifoQueue[T interface{}]() *FifoQueue[T] {
-	return &FifoQueue[T]{contents: make([]T, 0)}
+	return &FifoQueue[T]{contents: *newRing[T](defaultRingCapacity)}
 }
 
 /*
-O(1)
+O(capacity)
+Instantiates a new FifoQueue with room for capacity items before it has to grow
+The queue never shrinks below this capacity
+*/
+func NewFifoQueueWithCapacity[T interface{}](capacity int) *FifoQueue[T] {
+	return &FifoQueue[T]{contents: *newRing[T](capacity)}
+}
+
+/*
+O(1) amortized
 Assumes: the queue has been instantiated
 Places the item at the back of the queue
 */
 func (q *FifoQueue[T]) Enqueue(item T) {
-	q.contents = append(q.contents, item)
+	q.contents.pushBack(item)
 }
 
 /*
@@ -51,25 +60,22 @@ Iterates over the items and places each item at the back of the queue
 */
 func (q *FifoQueue[T]) EnqueueAll(items []T) {
 	for _, item := range items {
-		q.contents = append(q.contents, item)
+		q.contents.pushBack(item)
 	}
 }
 
 /*
-O(1)
+O(1) amortized
 Assumes: the queue has been instantiated
 Removes the item at the front of the queue and returns it
 Returns error if the queue is empty
 */
 func (q *FifoQueue[T]) Dequeue() (T, error) {
-	n := len(q.contents)
 	var nilVal T
-	if n == 0 {
-		return nilVal, errors.New(dequeueErrorMsg)
+	if q.contents.size == 0 {
+		return nilVal, &OpError{Op: "FifoQueue.Dequeue", Err: ErrEmpty}
 	}
-	item := q.contents[0]
-	q.contents = q.contents[1:]
-	return item, nil
+	return q.contents.popFront(), nil
 }
 
 /*
@@ -78,11 +84,8 @@ Assumes: the queue has been instantiated
 Removes all items in the queue and returns them as a slice in the order they were placed in the queue
 */
 func (q *FifoQueue[T]) DequeueAll() []T {
-	out := make([]T, 0)
-	for _, val := range q.contents {
-		out = append(out, val)
-	}
-	q.contents = make([]T, 0)
+	out := q.contents.toSlice()
+	q.contents.clear()
 	return out
 }
 
@@ -94,10 +97,10 @@ Returns error if the queue is empty
 */
 func (q FifoQueue[T]) Peek() (T, error) {
 	var nilVal T
-	if len(q.contents) == 0 {
-		return nilVal, errors.New(peekErrorMsg)
+	if q.contents.size == 0 {
+		return nilVal, &OpError{Op: "FifoQueue.Peek", Err: ErrEmpty}
 	}
-	return q.contents[0], nil
+	return q.contents.at(0), nil
 }
 
 /*
@@ -106,7 +109,7 @@ Assumes: the queue has been instantiated
 Returns true if there are no items in the queue
 */
 func (q FifoQueue[T]) IsEmpty() bool {
-	return len(q.contents) == 0
+	return q.contents.size == 0
 }
 
 /*
@@ -115,14 +118,14 @@ Assumes: the queue has been instantiated
 Returns the number of items in the queue
 */
 func (q FifoQueue[T]) Size() int {
-	return len(q.contents)
+	return q.contents.size
 }
 
 /*
-Replaces the internal queue with an empty queue
+Replaces the internal queue with an empty queue of the initial capacity
 */
 func (q *FifoQueue[T]) Clear() {
-	q.contents = make([]T, 0)
+	q.contents.clear()
 }
 
 /*
@@ -130,9 +133,45 @@ Assumes: the queue has been instantiated
 Returns the queue as a slice
 */
 func (q FifoQueue[T]) ToSlice() []T {
-	out := make([]T, 0)
-	for _, val := range q.contents {
-		out = append(out, val)
+	return q.contents.toSlice()
+}
+
+/*
+O(1)
+Assumes: the queue has been instantiated
+Returns an iterator over the positions and items from the front to the back
+The queue must not be modified during the iteration
+*/
+func (q FifoQueue[T]) All() iter.Seq2[int, T] {
+	return q.contents.all()
+}
+
+/*
+O(1)
+Assumes: the queue has been instantiated
+Returns an iterator over the items from the front to the back
+*/
+func (q FifoQueue[T]) Values() iter.Seq[T] {
+	return q.contents.values()
+}
+
+/*
+O(1)
+Assumes: the queue has been instantiated
+Returns an iterator over the positions and items from the back to the front
+*/
+func (q FifoQueue[T]) Backward() iter.Seq2[int, T] {
+	return q.contents.backward()
+}
+
+/*
+O(n)
+Instantiates a new FifoQueue and enqueues the items of the iterator in order
+*/
+func CollectFifoQueue[T interface{}](seq iter.Seq[T]) *FifoQueue[T] {
+	q := NewFifoQueue[T]()
+	for item := range seq {
+		q.Enqueue(item)
 	}
-	return out
+	return q
 }
@@ -3,6 +3,7 @@ package utils
 import (
 	"errors"
 	"reflect"
+	"slices"
 	"testing"
 )
 
@@ -100,9 +101,8 @@ func TestFifoQueue_Peek(t *testing.T) {
 
 	// Attempt to Peek when the queue is empty
 	item, err := q.Peek()
-	expectedError := errors.New(peekErrorMsg)
-	if !reflect.DeepEqual(err.Error(), expectedError.Error()) {
-		t.Errorf("Peek should return an error when the queue is empty. Got error: %v, expected error: %v", err, expectedError)
+	if !errors.Is(err, ErrEmpty) {
+		t.Errorf("Peek should return an error when the queue is empty. Got error: %v, expected error: %v", err, ErrEmpty)
 	}
 
 	// Enqueue some items
@@ -141,3 +141,139 @@ func TestFifoQueue_ToSlice(t *testing.T) {
 		t.Errorf("ToSlice should return the queue as a slice. Got %v, expected %v", slice, expected)
 	}
 }
+
+func TestFifoQueue_ZeroValue(t *testing.T) {
+	// A zero value queue works without a constructor
+	var q FifoQueue[int]
+	if !q.IsEmpty() {
+		t.Errorf("A zero value queue should be empty")
+	}
+	if _, err := q.Dequeue(); err == nil {
+		t.Errorf("Dequeue should return an error when the queue is empty")
+	}
+	for i := 0; i < 20; i++ {
+		q.Enqueue(i)
+	}
+	for i := 0; i < 20; i++ {
+		item, err := q.Dequeue()
+		if err != nil || item != i {
+			t.Errorf("Expected %d, but got %d (err: %v)", i, item, err)
+		}
+	}
+
+	var cleared FifoQueue[int]
+	cleared.Clear()
+	cleared.EnqueueAll([]int{1, 2})
+	if !reflect.DeepEqual(cleared.ToSlice(), []int{1, 2}) {
+		t.Errorf("Expected [1 2] after Clear on a zero value queue, but got %v", cleared.ToSlice())
+	}
+}
+
+func TestFifoQueue_WrapAround(t *testing.T) {
+	// Create a queue with a small buffer so the contents wrap around
+	q := NewFifoQueueWithCapacity[int](4)
+
+	// Interleave enqueues and dequeues so the head moves past the end of the buffer
+	next := 0
+	for i := 0; i < 3; i++ {
+		q.Enqueue(i)
+	}
+	for i := 3; i < 20; i++ {
+		q.Enqueue(i)
+		item, err := q.Dequeue()
+		if err != nil {
+			t.Errorf("Dequeue returned an error: %v", err)
+		}
+		if item != next {
+			t.Errorf("Dequeued item is not as expected. Got %v, expected %v", item, next)
+		}
+		next++
+	}
+
+	// Verify that the buffer never had to grow
+	if len(q.contents.buf) != 4 {
+		t.Errorf("Expected buffer capacity 4, but got %d", len(q.contents.buf))
+	}
+
+	slice := q.ToSlice()
+	expected := []int{17, 18, 19}
+	if !reflect.DeepEqual(slice, expected) {
+		t.Errorf("ToSlice should return the queue as a slice. Got %v, expected %v", slice, expected)
+	}
+}
+
+func TestFifoQueue_GrowAndShrink(t *testing.T) {
+	// Create a new FifoQueue
+	q := NewFifoQueue[int]()
+
+	// Fill the queue well past its initial capacity
+	for i := 0; i < 1000; i++ {
+		q.Enqueue(i)
+	}
+	if len(q.contents.buf) < 1000 {
+		t.Errorf("Expected buffer to grow to at least 1000, but got %d", len(q.contents.buf))
+	}
+
+	// Drain most of the queue and verify the order is kept
+	for i := 0; i < 995; i++ {
+		item, _ := q.Dequeue()
+		if item != i {
+			t.Errorf("Dequeued item is not as expected. Got %v, expected %v", item, i)
+		}
+	}
+
+	// Verify that the buffer has been released down to a small size
+	if len(q.contents.buf) > 4*defaultRingCapacity {
+		t.Errorf("Expected buffer to shrink, but capacity is still %d", len(q.contents.buf))
+	}
+	if q.Size() != 5 {
+		t.Errorf("Expected size 5, but got %d", q.Size())
+	}
+	item, _ := q.Peek()
+	if item != 995 {
+		t.Errorf("Peek is not as expected. Got %v, expected 995", item)
+	}
+}
+
+func TestFifoQueue_ClearKeepsCapacity(t *testing.T) {
+	// Create a queue with a custom initial capacity
+	q := NewFifoQueueWithCapacity[int](16)
+	for i := 0; i < 100; i++ {
+		q.Enqueue(i)
+	}
+
+	// Clear the queue and verify that it returns to its initial capacity
+	q.Clear()
+	if !q.IsEmpty() {
+		t.Errorf("Queue should be empty after Clear")
+	}
+	if len(q.contents.buf) != 16 {
+		t.Errorf("Expected buffer capacity 16 after Clear, but got %d", len(q.contents.buf))
+	}
+}
+
+func TestFifoQueue_Iterators(t *testing.T) {
+	q := CollectFifoQueue(slices.Values([]int{1, 2, 3}))
+	// Move the head so the iteration has to wrap around the buffer
+	q.Dequeue()
+	q.Enqueue(4)
+
+	forward := make([]int, 0)
+	for i, v := range q.All() {
+		if i != len(forward) {
+			t.Errorf("Expected position %d, but got %d", len(forward), i)
+		}
+		forward = append(forward, v)
+	}
+	if !reflect.DeepEqual(forward, []int{2, 3, 4}) || !slices.Equal(slices.Collect(q.Values()), forward) {
+		t.Errorf("Expected All and Values to go from front to back, but got %v", forward)
+	}
+
+	backward := make([]int, 0)
+	for _, v := range q.Backward() {
+		backward = append(backward, v)
+	}
+	if !reflect.DeepEqual(backward, []int{4, 3, 2}) {
+		t.Errorf("Expected Backward to go from back to front, but got %v", backward)
+	}
+}
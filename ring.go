@@ -0,0 +1,192 @@
+package utils
+
+import "iter"
+
+const defaultRingCapacity int = 8
+
+/*
+A growable circular buffer used as the backing store of the queue types
+The buffer doubles when full and halves when it is at most a quarter full,
+but never shrinks below the capacity it was created with.
+The zero value is an empty ring with the default capacity
+*/
+type ring[T interface{}] struct {
+	buf    []T
+	head   int
+	size   int
+	minCap int
+}
+
+/*
+O(capacity)
+Instantiates a new ring with room for at least capacity items
+Capacities smaller than 1 are replaced by the default capacity
+*/
+func newRing[T interface{}](capacity int) *ring[T] {
+	if capacity < 1 {
+		capacity = defaultRingCapacity
+	}
+	return &ring[T]{buf: make([]T, capacity), minCap: capacity}
+}
+
+/*
+O(1) amortized
+Places the item after the last item of the ring
+*/
+func (r *ring[T]) pushBack(item T) {
+	if r.size == len(r.buf) {
+		r.grow()
+	}
+	r.buf[r.index(r.size)] = item
+	r.size++
+}
+
+/*
+O(1) amortized
+Assumes: the ring is not empty
+Removes the first item of the ring and returns it
+*/
+func (r *ring[T]) popFront() T {
+	var nilVal T
+	item := r.buf[r.head]
+	r.buf[r.head] = nilVal
+	r.head = r.index(1)
+	r.size--
+	r.shrinkIfSparse()
+	return item
+}
+
+/*
+O(1) amortized
+Places the item before the first item of the ring
+*/
+func (r *ring[T]) pushFront(item T) {
+	if r.size == len(r.buf) {
+		r.grow()
+	}
+	r.head = r.index(len(r.buf) - 1)
+	r.buf[r.head] = item
+	r.size++
+}
+
+/*
+O(1) amortized
+Assumes: the ring is not empty
+Removes the last item of the ring and returns it
+*/
+func (r *ring[T]) popBack() T {
+	var nilVal T
+	last := r.index(r.size - 1)
+	item := r.buf[last]
+	r.buf[last] = nilVal
+	r.size--
+	r.shrinkIfSparse()
+	return item
+}
+
+/*
+O(1)
+Assumes: 0 <= i < r.size
+Returns the i:th item counted from the front
+*/
+func (r *ring[T]) at(i int) T {
+	return r.buf[r.index(i)]
+}
+
+/*
+O(n)
+Returns the items from front to back as a new slice
+*/
+func (r *ring[T]) toSlice() []T {
+	out := make([]T, r.size)
+	for i := 0; i < r.size; i++ {
+		out[i] = r.at(i)
+	}
+	return out
+}
+
+/*
+O(1)
+Replaces the buffer with an empty buffer of the initial capacity
+*/
+func (r *ring[T]) clear() {
+	r.buf = make([]T, r.floor())
+	r.head = 0
+	r.size = 0
+}
+
+/*
+O(1)
+Returns an iterator over the positions and items from front to back
+*/
+func (r *ring[T]) all() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < r.size; i++ {
+			if !yield(i, r.at(i)) {
+				return
+			}
+		}
+	}
+}
+
+/*
+O(1)
+Returns an iterator over the items from front to back
+*/
+func (r *ring[T]) values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < r.size; i++ {
+			if !yield(r.at(i)) {
+				return
+			}
+		}
+	}
+}
+
+/*
+O(1)
+Returns an iterator over the positions and items from back to front
+*/
+func (r *ring[T]) backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := r.size - 1; i >= 0; i-- {
+			if !yield(i, r.at(i)) {
+				return
+			}
+		}
+	}
+}
+
+// PRIVATE HELPER FUNCTIONS BELOW
+
+// physical index of the i:th item
+func (r *ring[T]) index(i int) int {
+	return (r.head + i) % len(r.buf)
+}
+
+// the capacity the ring never shrinks below, the default capacity for the zero value
+func (r *ring[T]) floor() int {
+	if r.minCap < 1 {
+		return defaultRingCapacity
+	}
+	return r.minCap
+}
+
+func (r *ring[T]) grow() {
+	r.resize(max(2*len(r.buf), r.floor()))
+}
+
+func (r *ring[T]) shrinkIfSparse() {
+	if len(r.buf) > r.floor() && r.size <= len(r.buf)/4 {
+		r.resize(max(len(r.buf)/2, r.floor()))
+	}
+}
+
+func (r *ring[T]) resize(capacity int) {
+	buf := make([]T, capacity)
+	for i := 0; i < r.size; i++ {
+		buf[i] = r.at(i)
+	}
+	r.buf = buf
+	r.head = 0
+}
@@ -1,10 +1,16 @@
 package utils
 
+import (
+	"iter"
+	"maps"
+	"slices"
+)
+
 type Set[T comparable] interface {
 	Add(item T) bool
-	AddAll(item []T)
+	AddAll(item []T) int
 	Remove(item T) bool
-	RemoveAll(item []T)
+	RemoveAll(item []T) int
 	Contains(item T) bool
 	Clear()
 	Size() int
@@ -24,15 +30,23 @@ O(1)
 Adds item to the set. Returns true if the item wasn't there before
 */
 func (s *HashSet[T]) Add(item T) bool {
-	res := s.items[item]
+	existed := s.items[item]
 	s.items[item] = true
-	return res
+	return !existed
 }
 
-func (s *HashSet[T]) AddAll(items []T) {
+/*
+O(n)
+Adds all items in the argument list. Returns the number of items that weren't there before
+*/
+func (s *HashSet[T]) AddAll(items []T) int {
+	added := 0
 	for _, item := range items {
-		s.items[item] = true
+		if s.Add(item) {
+			added++
+		}
 	}
+	return added
 }
 
 /*
@@ -51,12 +65,16 @@ func (s *HashSet[T]) Remove(item T) bool {
 
 /*
 O(n)
-Removes all items in the argument list
+Removes all items in the argument list. Returns the number of items that were there
 */
-func (s *HashSet[T]) RemoveAll(items []T) {
+func (s *HashSet[T]) RemoveAll(items []T) int {
+	removed := 0
 	for _, i := range items {
-		delete(s.items, i)
+		if s.Remove(i) {
+			removed++
+		}
 	}
+	return removed
 }
 
 /*
@@ -82,3 +100,282 @@ func (s HashSet[T]) ToSlice() []T {
 func (s HashSet[T]) Contains(item T) bool {
 	return s.items[item]
 }
+
+/*
+O(m) where m is the size of other
+Adds every item of other to the set
+*/
+func (s *HashSet[T]) UnionWith(other Set[T]) {
+	UnionWith[T](s, other)
+}
+
+/*
+O(min(n, m))
+Removes every item that is not in other
+*/
+func (s *HashSet[T]) IntersectWith(other Set[T]) {
+	IntersectWith[T](s, other)
+}
+
+/*
+O(min(n, m))
+Removes every item that is in other
+*/
+func (s *HashSet[T]) DifferenceWith(other Set[T]) {
+	DifferenceWith[T](s, other)
+}
+
+/*
+O(m) where m is the size of other
+Keeps the items that are in exactly one of the set and other
+*/
+func (s *HashSet[T]) SymmetricDifferenceWith(other Set[T]) {
+	SymmetricDifferenceWith[T](s, other)
+}
+
+/*
+O(n)
+Returns true if every item of the set is in other
+*/
+func (s HashSet[T]) IsSubsetOf(other Set[T]) bool {
+	return IsSubset[T](&s, other)
+}
+
+/*
+O(m) where m is the size of other
+Returns true if every item of other is in the set
+*/
+func (s HashSet[T]) IsSupersetOf(other Set[T]) bool {
+	return IsSubset[T](other, &s)
+}
+
+/*
+O(min(n, m))
+Returns true if the set and other have no items in common
+*/
+func (s HashSet[T]) IsDisjoint(other Set[T]) bool {
+	return IsDisjoint[T](&s, other)
+}
+
+/*
+O(n)
+Returns true if the set and other contain exactly the same items
+*/
+func (s HashSet[T]) Equals(other Set[T]) bool {
+	return SetsEqual[T](&s, other)
+}
+
+/*
+O(m) where m is the size of other
+Adds every item of other to dst
+*/
+func UnionWith[T comparable](dst Set[T], other Set[T]) {
+	for item := range itemsOf(other) {
+		dst.Add(item)
+	}
+}
+
+/*
+O(min(n, m))
+Removes every item of dst that is not in other
+*/
+func IntersectWith[T comparable](dst Set[T], other Set[T]) {
+	if dst.Size() <= other.Size() {
+		for item := range itemsOf(dst) {
+			if !other.Contains(item) {
+				dst.Remove(item)
+			}
+		}
+		return
+	}
+	kept := make([]T, 0, other.Size())
+	for item := range itemsOf(other) {
+		if dst.Contains(item) {
+			kept = append(kept, item)
+		}
+	}
+	dst.Clear()
+	dst.AddAll(kept)
+}
+
+/*
+O(min(n, m))
+Removes every item of dst that is in other
+*/
+func DifferenceWith[T comparable](dst Set[T], other Set[T]) {
+	if dst.Size() <= other.Size() {
+		for item := range itemsOf(dst) {
+			if other.Contains(item) {
+				dst.Remove(item)
+			}
+		}
+		return
+	}
+	for item := range itemsOf(other) {
+		dst.Remove(item)
+	}
+}
+
+/*
+O(m) where m is the size of other
+Keeps the items that are in exactly one of dst and other
+*/
+func SymmetricDifferenceWith[T comparable](dst Set[T], other Set[T]) {
+	for item := range itemsOf(other) {
+		if !dst.Remove(item) {
+			dst.Add(item)
+		}
+	}
+}
+
+/*
+O(n + m)
+Returns a new set with the items that are in a or b
+*/
+func Union[T comparable](a Set[T], b Set[T]) *HashSet[T] {
+	if a.Size() < b.Size() {
+		a, b = b, a
+	}
+	out := copyToHashSet(a)
+	out.UnionWith(b)
+	return out
+}
+
+/*
+O(min(n, m))
+Returns a new set with the items that are in both a and b
+*/
+func Intersection[T comparable](a Set[T], b Set[T]) *HashSet[T] {
+	if a.Size() > b.Size() {
+		a, b = b, a
+	}
+	out := NewHashSet[T]()
+	for item := range itemsOf(a) {
+		if b.Contains(item) {
+			out.items[item] = true
+		}
+	}
+	return out
+}
+
+/*
+O(n)
+Returns a new set with the items of a that are not in b
+*/
+func Difference[T comparable](a Set[T], b Set[T]) *HashSet[T] {
+	out := NewHashSet[T]()
+	for item := range itemsOf(a) {
+		if !b.Contains(item) {
+			out.items[item] = true
+		}
+	}
+	return out
+}
+
+/*
+O(n + m)
+Returns a new set with the items that are in exactly one of a and b
+*/
+func SymmetricDifference[T comparable](a Set[T], b Set[T]) *HashSet[T] {
+	out := copyToHashSet(a)
+	out.SymmetricDifferenceWith(b)
+	return out
+}
+
+/*
+O(n)
+Returns true if every item of a is in b
+*/
+func IsSubset[T comparable](a Set[T], b Set[T]) bool {
+	if a.Size() > b.Size() {
+		return false
+	}
+	for item := range itemsOf(a) {
+		if !b.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+O(m)
+Returns true if every item of b is in a
+*/
+func IsSuperset[T comparable](a Set[T], b Set[T]) bool {
+	return IsSubset(b, a)
+}
+
+/*
+O(min(n, m))
+Returns true if a and b have no items in common
+*/
+func IsDisjoint[T comparable](a Set[T], b Set[T]) bool {
+	if a.Size() > b.Size() {
+		a, b = b, a
+	}
+	for item := range itemsOf(a) {
+		if b.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+O(n)
+Returns true if a and b contain exactly the same items
+*/
+func SetsEqual[T comparable](a Set[T], b Set[T]) bool {
+	return a.Size() == b.Size() && IsSubset(a, b)
+}
+
+/*
+O(1)
+Returns an iterator over the items in no particular order
+The set must not be modified during the iteration
+*/
+func (s HashSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item := range s.items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+/*
+O(n)
+Instantiates a new HashSet holding the items of the iterator
+*/
+func CollectSet[T comparable](seq iter.Seq[T]) *HashSet[T] {
+	s := NewHashSet[T]()
+	for item := range seq {
+		s.items[item] = true
+	}
+	return s
+}
+
+// PRIVATE HELPER FUNCTIONS BELOW
+
+func copyToHashSet[T comparable](s Set[T]) *HashSet[T] {
+	if hs, ok := s.(*HashSet[T]); ok {
+		out := &HashSet[T]{items: make(map[T]bool, len(hs.items))}
+		maps.Copy(out.items, hs.items)
+		return out
+	}
+	out := NewHashSet[T]()
+	for _, item := range s.ToSlice() {
+		out.items[item] = true
+	}
+	return out
+}
+
+// ranges over the items of s, reading a HashSet's map directly instead of copying it with ToSlice
+func itemsOf[T comparable](s Set[T]) iter.Seq[T] {
+	if hs, ok := s.(*HashSet[T]); ok {
+		return hs.All()
+	}
+	return slices.Values(s.ToSlice())
+}
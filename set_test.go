@@ -0,0 +1,283 @@
+package utils
+
+import (
+	"reflect"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func hashSetOf(items ...int) *HashSet[int] {
+	s := NewHashSet[int]()
+	s.AddAll(items)
+	return s
+}
+
+// a Set that is not a HashSet, so the generic functions cannot read its items directly
+type sliceSet struct {
+	items []int
+}
+
+func (s *sliceSet) Add(item int) bool {
+	if s.Contains(item) {
+		return false
+	}
+	s.items = append(s.items, item)
+	return true
+}
+
+func (s *sliceSet) AddAll(items []int) int {
+	added := 0
+	for _, item := range items {
+		if s.Add(item) {
+			added++
+		}
+	}
+	return added
+}
+
+func (s *sliceSet) Remove(item int) bool {
+	i := slices.Index(s.items, item)
+	if i < 0 {
+		return false
+	}
+	s.items = slices.Delete(s.items, i, i+1)
+	return true
+}
+
+func (s *sliceSet) RemoveAll(items []int) int {
+	removed := 0
+	for _, item := range items {
+		if s.Remove(item) {
+			removed++
+		}
+	}
+	return removed
+}
+
+func (s *sliceSet) Contains(item int) bool { return slices.Contains(s.items, item) }
+func (s *sliceSet) Clear()                 { s.items = nil }
+func (s *sliceSet) Size() int              { return len(s.items) }
+func (s *sliceSet) ToSlice() []int         { return slices.Clone(s.items) }
+
+func sortedItems(s Set[int]) []int {
+	items := s.ToSlice()
+	sort.Ints(items)
+	return items
+}
+
+func TestSetAlgebra_PureFunctions(t *testing.T) {
+	a := hashSetOf(1, 2, 3, 4)
+	b := hashSetOf(3, 4, 5)
+
+	cases := []struct {
+		name     string
+		result   Set[int]
+		expected []int
+	}{
+		{"Union", Union[int](a, b), []int{1, 2, 3, 4, 5}},
+		{"Intersection", Intersection[int](a, b), []int{3, 4}},
+		{"Difference", Difference[int](a, b), []int{1, 2}},
+		{"Difference reversed", Difference[int](b, a), []int{5}},
+		{"SymmetricDifference", SymmetricDifference[int](a, b), []int{1, 2, 5}},
+	}
+	for _, c := range cases {
+		if items := sortedItems(c.result); !reflect.DeepEqual(items, c.expected) {
+			t.Errorf("%s: expected %v, but got %v", c.name, c.expected, items)
+		}
+	}
+
+	// The operands are left untouched
+	if !reflect.DeepEqual(sortedItems(a), []int{1, 2, 3, 4}) || !reflect.DeepEqual(sortedItems(b), []int{3, 4, 5}) {
+		t.Errorf("Expected the operands to be unchanged, but got %v and %v", sortedItems(a), sortedItems(b))
+	}
+}
+
+func TestSetAlgebra_InPlace(t *testing.T) {
+	cases := []struct {
+		name          string
+		apply         func(s *HashSet[int], other Set[int])
+		smallExpected []int
+		largeExpected []int
+	}{
+		{"UnionWith", (*HashSet[int]).UnionWith, []int{1, 2, 3, 4, 5, 6, 7}, []int{1, 2, 3, 4, 5}},
+		{"IntersectWith", (*HashSet[int]).IntersectWith, []int{3, 4}, []int{3, 4}},
+		{"DifferenceWith", (*HashSet[int]).DifferenceWith, []int{1, 2}, []int{1, 2}},
+		{"SymmetricDifferenceWith", (*HashSet[int]).SymmetricDifferenceWith, []int{1, 2, 5, 6, 7}, []int{1, 2, 5}},
+	}
+	for _, c := range cases {
+		// Run each operation with the receiver both smaller and larger than the argument
+		small := hashSetOf(1, 2, 3, 4)
+		c.apply(small, hashSetOf(3, 4, 5, 6, 7))
+		large := hashSetOf(1, 2, 3, 4)
+		c.apply(large, hashSetOf(3, 4, 5))
+
+		if items := sortedItems(small); !reflect.DeepEqual(items, c.smallExpected) {
+			t.Errorf("%s with a larger argument: expected %v, but got %v", c.name, c.smallExpected, items)
+		}
+		if items := sortedItems(large); !reflect.DeepEqual(items, c.largeExpected) {
+			t.Errorf("%s with a smaller argument: expected %v, but got %v", c.name, c.largeExpected, items)
+		}
+	}
+}
+
+func TestSetAlgebra_InPlaceAnySet(t *testing.T) {
+	cases := []struct {
+		name          string
+		apply         func(dst Set[int], other Set[int])
+		smallExpected []int
+		largeExpected []int
+	}{
+		{"UnionWith", UnionWith[int], []int{1, 2, 3, 4, 5, 6, 7}, []int{1, 2, 3, 4, 5}},
+		{"IntersectWith", IntersectWith[int], []int{3, 4}, []int{3, 4}},
+		{"DifferenceWith", DifferenceWith[int], []int{1, 2}, []int{1, 2}},
+		{"SymmetricDifferenceWith", SymmetricDifferenceWith[int], []int{1, 2, 5, 6, 7}, []int{1, 2, 5}},
+	}
+	for _, c := range cases {
+		// The destination is not a HashSet, and the argument is one in one run and not in the other
+		small := &sliceSet{items: []int{1, 2, 3, 4}}
+		c.apply(small, hashSetOf(3, 4, 5, 6, 7))
+		large := &sliceSet{items: []int{1, 2, 3, 4}}
+		c.apply(large, &sliceSet{items: []int{3, 4, 5}})
+
+		if items := sortedItems(small); !reflect.DeepEqual(items, c.smallExpected) {
+			t.Errorf("%s with a larger argument: expected %v, but got %v", c.name, c.smallExpected, items)
+		}
+		if items := sortedItems(large); !reflect.DeepEqual(items, c.largeExpected) {
+			t.Errorf("%s with a smaller argument: expected %v, but got %v", c.name, c.largeExpected, items)
+		}
+	}
+}
+
+func TestSetAlgebra_Predicates(t *testing.T) {
+	a := hashSetOf(1, 2)
+	b := hashSetOf(1, 2, 3)
+	c := hashSetOf(4, 5)
+	empty := NewHashSet[int]()
+
+	if !a.IsSubsetOf(b) || b.IsSubsetOf(a) {
+		t.Errorf("IsSubsetOf returned the wrong result")
+	}
+	if !b.IsSupersetOf(a) || a.IsSupersetOf(b) {
+		t.Errorf("IsSupersetOf returned the wrong result")
+	}
+	if !a.IsDisjoint(c) || a.IsDisjoint(b) {
+		t.Errorf("IsDisjoint returned the wrong result")
+	}
+	if !empty.IsSubsetOf(a) || !empty.IsDisjoint(a) || !a.IsSupersetOf(empty) {
+		t.Errorf("The empty set should be a disjoint subset of every set")
+	}
+	if !a.Equals(hashSetOf(2, 1)) || a.Equals(b) || a.Equals(hashSetOf(1, 3)) {
+		t.Errorf("Equals returned the wrong result")
+	}
+	if !IsSuperset[int](b, a) || !SetsEqual[int](c, hashSetOf(5, 4)) {
+		t.Errorf("The pure predicates returned the wrong result")
+	}
+}
+
+// runs the behaviour every Set implementation must share against the sets made by newSet
+func testSetConformance(t *testing.T, newSet func() Set[int]) {
+	t.Run("Add", func(t *testing.T) {
+		s := newSet()
+		if !s.Add(1) {
+			t.Errorf("Expected Add of a new item to return true")
+		}
+		if s.Add(1) {
+			t.Errorf("Expected Add of an existing item to return false")
+		}
+		if s.Size() != 1 || !s.Contains(1) {
+			t.Errorf("Expected the set to contain exactly 1, but got %v", s.ToSlice())
+		}
+	})
+
+	t.Run("AddAll", func(t *testing.T) {
+		s := newSet()
+		s.Add(2)
+		if added := s.AddAll([]int{1, 2, 3, 3}); added != 2 {
+			t.Errorf("Expected AddAll to add 2 items, but it reported %d", added)
+		}
+		if items := sortedItems(s); !reflect.DeepEqual(items, []int{1, 2, 3}) {
+			t.Errorf("Expected [1 2 3], but got %v", items)
+		}
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		s := newSet()
+		s.Add(1)
+		if !s.Remove(1) {
+			t.Errorf("Expected Remove of an existing item to return true")
+		}
+		if s.Remove(1) {
+			t.Errorf("Expected Remove of a missing item to return false")
+		}
+		if s.Contains(1) || s.Size() != 0 {
+			t.Errorf("Expected the set to be empty, but got %v", s.ToSlice())
+		}
+	})
+
+	t.Run("RemoveAll", func(t *testing.T) {
+		s := newSet()
+		s.AddAll([]int{1, 2, 3})
+		if removed := s.RemoveAll([]int{2, 3, 3, 4}); removed != 2 {
+			t.Errorf("Expected RemoveAll to remove 2 items, but it reported %d", removed)
+		}
+		if items := sortedItems(s); !reflect.DeepEqual(items, []int{1}) {
+			t.Errorf("Expected [1], but got %v", items)
+		}
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		s := newSet()
+		s.AddAll([]int{1, 2, 3})
+		s.Clear()
+		if s.Size() != 0 || len(s.ToSlice()) != 0 || s.Contains(1) {
+			t.Errorf("Expected the set to be empty after Clear, but got %v", s.ToSlice())
+		}
+		if !s.Add(1) {
+			t.Errorf("Expected Add after Clear to return true")
+		}
+	})
+
+	t.Run("ToSlice", func(t *testing.T) {
+		s := newSet()
+		s.AddAll([]int{3, 1, 2, 1})
+		items := s.ToSlice()
+		if len(items) != s.Size() {
+			t.Errorf("Expected %d items, but got %v", s.Size(), items)
+		}
+		// Changing the slice must not change the set
+		items[0] = 100
+		if s.Contains(100) {
+			t.Errorf("Expected ToSlice to return a copy")
+		}
+	})
+}
+
+func TestHashSet_Conformance(t *testing.T) {
+	testSetConformance(t, func() Set[int] { return NewHashSet[int]() })
+}
+
+func TestHashSet_DedupWithAdd(t *testing.T) {
+	seen := NewHashSet[string]()
+	unique := make([]string, 0)
+	for _, word := range []string{"a", "b", "a", "c", "b"} {
+		if seen.Add(word) {
+			unique = append(unique, word)
+		}
+	}
+	expected := []string{"a", "b", "c"}
+	if !reflect.DeepEqual(unique, expected) {
+		t.Errorf("Expected %v, but got %v", expected, unique)
+	}
+}
+
+func TestHashSet_Iterators(t *testing.T) {
+	s := CollectSet(hashSetOf(1, 2, 3).All())
+	sum := 0
+	for item := range s.All() {
+		sum += item
+	}
+	if s.Size() != 3 || sum != 6 {
+		t.Errorf("Expected to collect and visit [1 2 3], but got %v", sortedItems(s))
+	}
+}
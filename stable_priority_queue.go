@@ -0,0 +1,194 @@
+package utils
+
+import "iter"
+
+/*
+A PriorityQueue that dequeues items with equal priority in the order they were enqueued
+Every item is tagged with an increasing sequence number that breaks ties in the comparator,
+which makes the dequeue order fully deterministic. Implements the Queue interface
+*/
+type StablePriorityQueue[T interface{}] struct {
+	heap *PriorityQueue[stableItem[T]]
+	next uint64
+}
+
+type stableItem[T interface{}] struct {
+	item T
+	seq  uint64
+}
+
+/*
+O(1)
+Instantiates a new empty StablePriorityQueue ordered by comp
+*/
+func NewStablePriorityQueue[T interface{}](comp func(T, T) int) *StablePriorityQueue[T] {
+	return &StablePriorityQueue[T]{
+		heap: NewPriorityQueue(func(a, b stableItem[T]) int {
+			if order := comp(a.item, b.item); order != 0 {
+				return order
+			}
+			if a.seq < b.seq {
+				return -1
+			} else if a.seq > b.seq {
+				return 1
+			}
+			return 0
+		})}
+}
+
+/*
+O(log n)
+Assumes: StablePriorityQueue q has been instantiated
+Inserts the item behind every item with the same priority
+*/
+func (q *StablePriorityQueue[T]) Enqueue(item T) {
+	q.heap.Enqueue(q.tag(item))
+}
+
+/*
+O(min(m log(n + m), n + m)) where m is the number of items
+Assumes: StablePriorityQueue q has been instantiated
+Enqueues all items in the order of the slice
+*/
+func (q *StablePriorityQueue[T]) EnqueueAll(items []T) {
+	tagged := make([]stableItem[T], len(items))
+	for i, item := range items {
+		tagged[i] = q.tag(item)
+	}
+	q.heap.EnqueueAll(tagged)
+}
+
+/*
+O(log n)
+Assumes: StablePriorityQueue q has been instantiated
+Removes and returns the top item, which is the earliest enqueued of the items with the top priority
+Returns error if the queue is empty
+*/
+func (q *StablePriorityQueue[T]) Dequeue() (T, error) {
+	if q.heap.IsEmpty() {
+		var nilVal T
+		return nilVal, &OpError{Op: "StablePriorityQueue.Dequeue", Err: ErrEmpty}
+	}
+	top, _ := q.heap.Dequeue()
+	return top.item, nil
+}
+
+/*
+O(n log n)
+Assumes: StablePriorityQueue q has been instantiated
+Dequeues all items and returns them sorted by the comparator, with equal items in the order they were enqueued
+*/
+func (q *StablePriorityQueue[T]) DequeueAll() []T {
+	out := make([]T, 0, q.heap.Size())
+	for !q.heap.IsEmpty() {
+		top, _ := q.heap.Dequeue()
+		out = append(out, top.item)
+	}
+	return out
+}
+
+/*
+O(1)
+Assumes: StablePriorityQueue q has been instantiated
+Returns the top item without removing it
+Returns error if the queue is empty
+*/
+func (q StablePriorityQueue[T]) Peek() (T, error) {
+	if q.heap.IsEmpty() {
+		var nilVal T
+		return nilVal, &OpError{Op: "StablePriorityQueue.Peek", Err: ErrEmpty}
+	}
+	top, _ := q.heap.Peek()
+	return top.item, nil
+}
+
+/*
+O(1)
+Assumes: StablePriorityQueue q has been instantiated
+Returns true if the queue is empty
+*/
+func (q StablePriorityQueue[T]) IsEmpty() bool {
+	return q.heap.IsEmpty()
+}
+
+/*
+O(1)
+Assumes: StablePriorityQueue q has been instantiated
+Returns the number of items in the queue
+*/
+func (q StablePriorityQueue[T]) Size() int {
+	return q.heap.Size()
+}
+
+/*
+O(1)
+Wipes contents of the queue
+*/
+func (q *StablePriorityQueue[T]) Clear() {
+	q.heap.Clear()
+	q.next = 0
+}
+
+/*
+O(n)
+Assumes: StablePriorityQueue q has been instantiated
+Returns the inner representation of the queue as a slice
+*/
+func (q StablePriorityQueue[T]) ToSlice() []T {
+	out := make([]T, 0, q.heap.Size())
+	for _, tagged := range q.heap.contents {
+		out = append(out, tagged.item)
+	}
+	return out
+}
+
+/*
+O(n + k log n) for the first k items
+Assumes: StablePriorityQueue q has been instantiated
+Returns an iterator over the ranks and items in the order they would be dequeued, without changing the queue
+*/
+func (q StablePriorityQueue[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for rank, tagged := range q.heap.All() {
+			if !yield(rank, tagged.item) {
+				return
+			}
+		}
+	}
+}
+
+/*
+O(n + k log n) for the first k items
+Assumes: StablePriorityQueue q has been instantiated
+Returns an iterator over the items in the order they would be dequeued, without changing the queue
+*/
+func (q StablePriorityQueue[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, tagged := range q.heap.All() {
+			if !yield(tagged.item) {
+				return
+			}
+		}
+	}
+}
+
+/*
+O(n)
+Instantiates a new StablePriorityQueue holding the items of the iterator, in the order they are yielded
+*/
+func CollectStablePriorityQueue[T interface{}](seq iter.Seq[T], comp func(T, T) int) *StablePriorityQueue[T] {
+	q := NewStablePriorityQueue(comp)
+	for item := range seq {
+		q.heap.contents = append(q.heap.contents, q.tag(item))
+	}
+	q.heap.heapify()
+	return q
+}
+
+// PRIVATE HELPER FUNCTIONS BELOW
+
+func (q *StablePriorityQueue[T]) tag(item T) stableItem[T] {
+	tagged := stableItem[T]{item: item, seq: q.next}
+	q.next++
+	return tagged
+}
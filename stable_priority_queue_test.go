@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+type job struct {
+	priority int
+	name     string
+}
+
+func byPriority(a, b job) int {
+	return a.priority - b.priority
+}
+
+func TestStablePriorityQueue_DequeueAllIsStable(t *testing.T) {
+	jobs := []job{{2, "a"}, {1, "b"}, {2, "c"}, {1, "d"}, {3, "e"}, {2, "f"}, {1, "g"}}
+	expected := []job{{1, "b"}, {1, "d"}, {1, "g"}, {2, "a"}, {2, "c"}, {2, "f"}, {3, "e"}}
+
+	q := NewStablePriorityQueue(byPriority)
+	for _, j := range jobs {
+		q.Enqueue(j)
+	}
+	if out := q.DequeueAll(); !reflect.DeepEqual(out, expected) {
+		t.Errorf("Expected %v, but got %v", expected, out)
+	}
+
+	// The bulk paths must keep the same order
+	q.EnqueueAll(jobs)
+	if out := q.DequeueAll(); !reflect.DeepEqual(out, expected) {
+		t.Errorf("Expected EnqueueAll to be stable, but got %v", out)
+	}
+	collected := CollectStablePriorityQueue(slices.Values(jobs), byPriority)
+	if out := slices.Collect(collected.Values()); !reflect.DeepEqual(out, expected) {
+		t.Errorf("Expected Values to be stable, but got %v", out)
+	}
+}
+
+func TestStablePriorityQueue_ManyEqualItems(t *testing.T) {
+	q := NewStablePriorityQueue(byPriority)
+	for i := 0; i < 1000; i++ {
+		q.Enqueue(job{priority: rand.Intn(3), name: strconv.Itoa(i)})
+	}
+	visited := 0
+	for range q.All() {
+		visited++
+	}
+	if visited != 1000 || q.Size() != 1000 {
+		t.Errorf("Expected All to visit 1000 items without removing them, but visited %d", visited)
+	}
+
+	previous, _ := q.Dequeue()
+	for !q.IsEmpty() {
+		current, _ := q.Dequeue()
+		if current.priority < previous.priority {
+			t.Fatalf("Expected non-decreasing priorities, but %v came after %v", current, previous)
+		}
+		before, _ := strconv.Atoi(previous.name)
+		after, _ := strconv.Atoi(current.name)
+		if current.priority == previous.priority && after < before {
+			t.Fatalf("Expected equal priorities in insertion order, but %v came after %v", current, previous)
+		}
+		previous = current
+	}
+}
+
+func TestStablePriorityQueue_AsQueue(t *testing.T) {
+	var q Queue[job] = NewStablePriorityQueue(byPriority)
+	if _, err := q.Dequeue(); err == nil {
+		t.Errorf("Dequeue should return an error when the queue is empty")
+	}
+	if _, err := q.Peek(); err == nil {
+		t.Errorf("Peek should return an error when the queue is empty")
+	}
+	q.EnqueueAll([]job{{1, "x"}, {1, "y"}})
+	if top, _ := q.Peek(); top.name != "x" {
+		t.Errorf("Expected x in front, but got %s", top.name)
+	}
+	if len(q.ToSlice()) != 2 {
+		t.Errorf("Expected 2 items, but got %v", q.ToSlice())
+	}
+	q.Clear()
+	if !q.IsEmpty() {
+		t.Errorf("Expected an empty queue after Clear")
+	}
+}
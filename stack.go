@@ -1,8 +1,6 @@
 package utils
 
-import "errors"
-
-const emptyStackError = "Stack is empty"
+import "iter"
 
 type Stack[T interface{}] interface {
 	Push(item T)
@@ -37,7 +35,7 @@ func (s *SliceStack[T]) PushAll(items []T) {
 func (s *SliceStack[T]) Pop() (T, error) {
 	if len(s.items) == 0 {
 		var nilVal T
-		return nilVal, errors.New(emptyStackError)
+		return nilVal, &OpError{Op: "SliceStack.Pop", Err: ErrEmpty}
 	}
 	n := len(s.items)
 	item := s.items[n-1]
@@ -58,7 +56,7 @@ func (s *SliceStack[T]) PopAll() []T {
 func (s SliceStack[T]) Peek() (T, error) {
 	if len(s.items) == 0 {
 		var nilVal T
-		return nilVal, errors.New(emptyStackError)
+		return nilVal, &OpError{Op: "SliceStack.Peek", Err: ErrEmpty}
 	}
 	return s.items[len(s.items)-1], nil
 }
@@ -82,3 +80,58 @@ func (s SliceStack[T]) ToSlice() []T {
 func (s *SliceStack[T]) Clear() {
 	s.items = make([]T, 0)
 }
+
+/*
+O(1)
+Returns an iterator over the positions and items from the bottom to the top, the order of ToSlice
+The stack must not be modified during the iteration
+*/
+func (s SliceStack[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, item := range s.items {
+			if !yield(i, item) {
+				return
+			}
+		}
+	}
+}
+
+/*
+O(1)
+Returns an iterator over the items from the bottom to the top
+*/
+func (s SliceStack[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range s.items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+/*
+O(1)
+Returns an iterator over the positions and items from the top to the bottom, the order they would be popped
+*/
+func (s SliceStack[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := len(s.items) - 1; i >= 0; i-- {
+			if !yield(i, s.items[i]) {
+				return
+			}
+		}
+	}
+}
+
+/*
+O(n)
+Instantiates a new SliceStack and pushes the items of the iterator in order
+*/
+func CollectStack[T interface{}](seq iter.Seq[T]) *SliceStack[T] {
+	s := NewSliceStack[T]()
+	for item := range seq {
+		s.Push(item)
+	}
+	return s
+}
@@ -1,54 +1,94 @@
 package utils
 
 import (
+	"slices"
 	"testing"
 )
 
+// every Stack implementation, so the stack tests check that they can replace each other
+var stackImplementations = map[string]func() Stack[int]{
+	"SliceStack":      func() Stack[int] { return NewSliceStack[int]() },
+	"ConcurrentStack": func() Stack[int] { return NewConcurrentStack[int]() },
+	"DequeStack":      func() Stack[int] { return NewDeque[int]().AsStack() },
+}
+
 func TestPushAndPop(t *testing.T) {
-	var s Stack[int] = NewSliceStack[int]()
-	test := []int{1, 2, 3, 4, 5}
-	s.PushAll(test)
-	if s.Size() != 5 {
-		t.Errorf("Expected size: %d, but actual was: %d for input %v", 5, s.Size(), test)
-	}
-	result := s.ToSlice()
-	for i, v := range test {
-		if result[i] != v {
-			t.Errorf("Expected: %v but got Actual: %v", test, result)
+	for name, newStack := range stackImplementations {
+		s := newStack()
+		test := []int{1, 2, 3, 4, 5}
+		s.PushAll(test)
+		if s.Size() != 5 {
+			t.Errorf("%s: Expected size: %d, but actual was: %d for input %v", name, 5, s.Size(), test)
 		}
-	}
-	result = s.PopAll()
-	for i, v := range test {
-		if result[4-i] != v {
-			t.Fail()
+		result := s.ToSlice()
+		for i, v := range test {
+			if result[i] != v {
+				t.Errorf("%s: Expected: %v but got Actual: %v", name, test, result)
+			}
+		}
+		result = s.PopAll()
+		for i, v := range test {
+			if result[4-i] != v {
+				t.Errorf("%s: Expected PopAll to return %v reversed, but got %v", name, test, result)
+			}
 		}
 	}
 }
 
 func TestPushAndPop2(t *testing.T) {
-	var s Stack[int] = NewSliceStack[int]()
-	s.Push(1)
-	res, _ := s.Peek()
-	if res != 1 {
-		t.Errorf("Expected: %d | Actual: %d", 1, res)
-	}
-	s.Push(2)
-	res, _ = s.Peek()
-	if res != 2 {
-		t.Errorf("Expected: %d | Actual: %d", 2, res)
-	}
-	s.Pop()
-	res, _ = s.Peek()
-	if res != 1 {
-		t.Errorf("Expected: %d | Actual: %d", 1, res)
+	for name, newStack := range stackImplementations {
+		s := newStack()
+		s.Push(1)
+		res, _ := s.Peek()
+		if res != 1 {
+			t.Errorf("%s: Expected: %d | Actual: %d", name, 1, res)
+		}
+		s.Push(2)
+		res, _ = s.Peek()
+		if res != 2 {
+			t.Errorf("%s: Expected: %d | Actual: %d", name, 2, res)
+		}
+		s.Pop()
+		res, _ = s.Peek()
+		if res != 1 {
+			t.Errorf("%s: Expected: %d | Actual: %d", name, 1, res)
+		}
 	}
 }
 
 func TestClear(t *testing.T) {
-	var s Stack[int] = NewSliceStack[int]()
-	s.PushAll([]int{1, 2, 3, 4})
-	s.Clear()
-	if s.Size() != 0 {
-		t.Errorf("Expected stackto be empty but has size: %d", s.Size())
+	for name, newStack := range stackImplementations {
+		s := newStack()
+		s.PushAll([]int{1, 2, 3, 4})
+		s.Clear()
+		if s.Size() != 0 || !s.IsEmpty() {
+			t.Errorf("%s: Expected stackto be empty but has size: %d", name, s.Size())
+		}
+	}
+}
+
+func TestSliceStack_Iterators(t *testing.T) {
+	s := CollectStack(slices.Values([]int{1, 2, 3}))
+
+	forward := make([]int, 0)
+	for i, v := range s.All() {
+		if i != len(forward) {
+			t.Errorf("Expected position %d, but got %d", len(forward), i)
+		}
+		forward = append(forward, v)
+	}
+	if !slices.Equal(forward, []int{1, 2, 3}) || !slices.Equal(slices.Collect(s.Values()), []int{1, 2, 3}) {
+		t.Errorf("Expected All and Values to go from the bottom to the top, but got %v", forward)
+	}
+
+	backward := make([]int, 0)
+	for _, v := range s.Backward() {
+		backward = append(backward, v)
+	}
+	if !slices.Equal(backward, []int{3, 2, 1}) {
+		t.Errorf("Expected Backward to go in pop order, but got %v", backward)
+	}
+	if s.Size() != 3 {
+		t.Errorf("Expected the iterators to leave the stack untouched")
 	}
 }
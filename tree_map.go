@@ -0,0 +1,650 @@
+package utils
+
+import "iter"
+
+/*
+A sorted implementation of the Map interface
+Backed by a left-leaning red-black tree ordered by the supplied comparator,
+so Keys and Values are returned in ascending key order
+*/
+type TreeMap[K comparable, V interface{}] struct {
+	comparator func(K, K) int
+	root       *treeNode[K, V]
+	size       int
+}
+
+type treeNode[K comparable, V interface{}] struct {
+	key   K
+	value V
+	left  *treeNode[K, V]
+	right *treeNode[K, V]
+	red   bool
+}
+
+/*
+O(1)
+Instantiates a new empty TreeMap
+The comparator returns a negative number if a < b, 0 if a == b and a positive number if a > b
+*/
+func NewTreeMap[K comparable, V interface{}](comp func(K, K) int) *TreeMap[K, V] {
+	return &TreeMap[K, V]{comparator: comp}
+}
+
+/*
+O(log n)
+Assumes: TreeMap m has been instantiated
+Ensures: the key is mapped to the value
+*/
+func (m *TreeMap[K, V]) Put(key K, value V) {
+	m.root = m.put(m.root, key, value)
+	m.root.red = false
+}
+
+/*
+O(log n)
+Assumes: TreeMap m has been instantiated
+Returns the value that is associated with the supplied key
+If there is no mapping, the function will return the nil value of the value type
+*/
+func (m TreeMap[K, V]) Get(key K) V {
+	var nilVal V
+	node := m.find(key)
+	if node == nil {
+		return nilVal
+	}
+	return node.value
+}
+
+/*
+O(log n)
+Assumes: TreeMap m has been instantiated
+Returns the value that is associated with the supplied key and true,
+or the nil value of the value type and false if there is no mapping
+*/
+func (m TreeMap[K, V]) GetOk(key K) (V, bool) {
+	node := m.find(key)
+	if node == nil {
+		var nilVal V
+		return nilVal, false
+	}
+	return node.value, true
+}
+
+/*
+O(log n)
+Assumes: TreeMap m has been instantiated
+Returns the value that is associated with the supplied key, or defaultValue if there is no mapping
+*/
+func (m TreeMap[K, V]) GetOrDefault(key K, defaultValue V) V {
+	if node := m.find(key); node != nil {
+		return node.value
+	}
+	return defaultValue
+}
+
+/*
+O(log n)
+Assumes: TreeMap m has been instantiated
+Maps the key to the value only if there is no mapping with the supplied key
+Returns the value the key is mapped to afterwards and true if it was already mapped
+*/
+func (m *TreeMap[K, V]) PutIfAbsent(key K, value V) (V, bool) {
+	if node := m.find(key); node != nil {
+		return node.value, true
+	}
+	m.Put(key, value)
+	return value, false
+}
+
+/*
+O(log n)
+Assumes: TreeMap m has been instantiated
+Maps the key to the value only if there already is a mapping with the supplied key
+Returns the replaced value and true, or the nil value of the value type and false if nothing was replaced
+*/
+func (m *TreeMap[K, V]) Replace(key K, value V) (V, bool) {
+	node := m.find(key)
+	if node == nil {
+		var nilVal V
+		return nilVal, false
+	}
+	old := node.value
+	node.value = value
+	return old, true
+}
+
+/*
+O(log n)
+Assumes: TreeMap m has been instantiated
+Removes the mapping with the supplied key
+Returns the removed value and true, or the nil value of the value type and false if there was no mapping
+*/
+func (m *TreeMap[K, V]) Remove(key K) (V, bool) {
+	node := m.find(key)
+	if node == nil {
+		var nilVal V
+		return nilVal, false
+	}
+	// the node may be overwritten by its successor during the removal
+	value := node.value
+	if !isRed(m.root.left) && !isRed(m.root.right) {
+		m.root.red = true
+	}
+	m.root = m.remove(m.root, key)
+	if m.root != nil {
+		m.root.red = false
+	}
+	m.size--
+	return value, true
+}
+
+/*
+O(n)
+Assumes: TreeMap m has been instantiated
+Returns the keys in ascending order
+*/
+func (m TreeMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.size)
+	m.Ascend(func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return keys
+}
+
+/*
+O(n)
+Assumes: TreeMap m has been instantiated
+Returns the values ordered by their keys in ascending order
+*/
+func (m TreeMap[K, V]) Values() []V {
+	vals := make([]V, 0, m.size)
+	m.Ascend(func(_ K, v V) bool {
+		vals = append(vals, v)
+		return true
+	})
+	return vals
+}
+
+/*
+O(log n)
+Assumes: TreeMap m has been instantiated
+Returns true if there exist a mapping with the supplied key
+*/
+func (m TreeMap[K, V]) ContainsKey(key K) bool {
+	return m.find(key) != nil
+}
+
+/*
+O(log n)
+Assumes: TreeMap m has been instantiated
+If there exists a mapping with the supplied key, the new value will be mergeOp(newVal, oldVal)
+If not, it works as a regular Put
+*/
+func (m *TreeMap[K, V]) Merge(key K, newVal V, mergeOp func(V, V) V) {
+	if node := m.find(key); node != nil {
+		node.value = mergeOp(newVal, node.value)
+	} else {
+		m.Put(key, newVal)
+	}
+}
+
+/*
+O(log n)
+Assumes: TreeMap m has been instantiated
+Updates the mapping with the supplied key
+remap receives the current value and whether it exists, and returns the new value
+and whether the mapping should exist afterwards, so returning false removes it
+Returns the value after the update and whether the mapping exists
+*/
+func (m *TreeMap[K, V]) Compute(key K, remap func(V, bool) (V, bool)) (V, bool) {
+	var old V
+	node := m.find(key)
+	if node != nil {
+		old = node.value
+	}
+	value, keep := remap(old, node != nil)
+	if !keep {
+		if node != nil {
+			m.Remove(key)
+		}
+		var nilVal V
+		return nilVal, false
+	}
+	if node != nil {
+		node.value = value
+	} else {
+		m.Put(key, value)
+	}
+	return value, true
+}
+
+/*
+O(log n)
+Assumes: TreeMap m has been instantiated
+Adds a mapping with the value returned by create if there is no mapping with the supplied key
+Nothing is added if create returns false
+Returns the value after the update and whether the mapping exists
+*/
+func (m *TreeMap[K, V]) ComputeIfAbsent(key K, create func() (V, bool)) (V, bool) {
+	return m.Compute(key, absentRemap(create))
+}
+
+/*
+O(log n)
+Assumes: TreeMap m has been instantiated
+Replaces the value of an existing mapping with the value returned by remap
+The mapping is removed if remap returns false, and nothing happens if there is no mapping
+Returns the value after the update and whether the mapping exists
+*/
+func (m *TreeMap[K, V]) ComputeIfPresent(key K, remap func(V) (V, bool)) (V, bool) {
+	return m.Compute(key, presentRemap(remap))
+}
+
+/*
+O(1)
+Assumes: TreeMap m has been instantiated
+Returns the number of mappings
+*/
+func (m TreeMap[K, V]) Size() int {
+	return m.size
+}
+
+/*
+O(log n)
+Assumes: TreeMap m has been instantiated
+Returns the smallest key
+Returns error if the map is empty
+*/
+func (m TreeMap[K, V]) First() (K, error) {
+	var nilVal K
+	if m.root == nil {
+		return nilVal, &OpError{Op: "TreeMap.First", Err: ErrEmpty}
+	}
+	return minNode(m.root).key, nil
+}
+
+/*
+O(log n)
+Assumes: TreeMap m has been instantiated
+Returns the largest key
+Returns error if the map is empty
+*/
+func (m TreeMap[K, V]) Last() (K, error) {
+	var nilVal K
+	if m.root == nil {
+		return nilVal, &OpError{Op: "TreeMap.Last", Err: ErrEmpty}
+	}
+	node := m.root
+	for node.right != nil {
+		node = node.right
+	}
+	return node.key, nil
+}
+
+/*
+O(log n)
+Assumes: TreeMap m has been instantiated
+Returns the largest key less than or equal to the supplied key
+The boolean is false if there is no such key
+*/
+func (m TreeMap[K, V]) Floor(key K) (K, bool) {
+	return m.below(key, true)
+}
+
+/*
+O(log n)
+Assumes: TreeMap m has been instantiated
+Returns the largest key strictly less than the supplied key
+The boolean is false if there is no such key
+*/
+func (m TreeMap[K, V]) Lower(key K) (K, bool) {
+	return m.below(key, false)
+}
+
+/*
+O(log n)
+Assumes: TreeMap m has been instantiated
+Returns the smallest key greater than or equal to the supplied key
+The boolean is false if there is no such key
+*/
+func (m TreeMap[K, V]) Ceiling(key K) (K, bool) {
+	return m.above(key, true)
+}
+
+/*
+O(log n)
+Assumes: TreeMap m has been instantiated
+Returns the smallest key strictly greater than the supplied key
+The boolean is false if there is no such key
+*/
+func (m TreeMap[K, V]) Higher(key K) (K, bool) {
+	return m.above(key, false)
+}
+
+/*
+O(n)
+Assumes: TreeMap m has been instantiated
+Calls visit on every mapping in ascending key order until visit returns false
+*/
+func (m TreeMap[K, V]) Ascend(visit func(K, V) bool) {
+	ascend(m.root, visit)
+}
+
+/*
+O(n)
+Assumes: TreeMap m has been instantiated
+Calls visit on every mapping in descending key order until visit returns false
+*/
+func (m TreeMap[K, V]) Descend(visit func(K, V) bool) {
+	descend(m.root, visit)
+}
+
+/*
+O(log n + k) where k is the number of visited mappings
+Assumes: TreeMap m has been instantiated
+Calls visit in ascending key order on every mapping with from <= key < to until visit returns false
+*/
+func (m TreeMap[K, V]) AscendRange(from K, to K, visit func(K, V) bool) {
+	m.ascendRange(m.root, from, to, visit)
+}
+
+/*
+O(log n + k) where k is the number of keys in the range
+Assumes: TreeMap m has been instantiated
+Returns the keys with from <= key < to in ascending order
+*/
+func (m TreeMap[K, V]) KeysBetween(from K, to K) []K {
+	keys := make([]K, 0)
+	m.AscendRange(from, to, func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return keys
+}
+
+/*
+O(1)
+Assumes: TreeMap m has been instantiated
+Returns an iterator over the mappings in ascending key order
+The map must not be modified during the iteration
+*/
+func (m TreeMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		ascend(m.root, yield)
+	}
+}
+
+/*
+O(1)
+Assumes: TreeMap m has been instantiated
+Returns an iterator over the mappings in descending key order
+*/
+func (m TreeMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		descend(m.root, yield)
+	}
+}
+
+/*
+O(1)
+Assumes: TreeMap m has been instantiated
+Returns an iterator over the keys in ascending order, without allocating a slice like Keys does
+*/
+func (m TreeMap[K, V]) KeysSeq() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		ascend(m.root, func(k K, _ V) bool { return yield(k) })
+	}
+}
+
+/*
+O(1)
+Assumes: TreeMap m has been instantiated
+Returns an iterator over the values in ascending key order, without allocating a slice like Values does
+*/
+func (m TreeMap[K, V]) ValuesSeq() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		ascend(m.root, func(_ K, v V) bool { return yield(v) })
+	}
+}
+
+/*
+O(1)
+Assumes: TreeMap m has been instantiated
+Returns an iterator over the mappings with from <= key < to in ascending key order
+*/
+func (m TreeMap[K, V]) Range(from K, to K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.ascendRange(m.root, from, to, yield)
+	}
+}
+
+/*
+O(n log n)
+Instantiates a new TreeMap holding the mappings of the iterator
+Later mappings overwrite earlier mappings with the same key
+*/
+func CollectTreeMap[K comparable, V interface{}](seq iter.Seq2[K, V], comp func(K, K) int) *TreeMap[K, V] {
+	m := NewTreeMap[K, V](comp)
+	for k, v := range seq {
+		m.Put(k, v)
+	}
+	return m
+}
+
+// PRIVATE HELPER FUNCTIONS BELOW
+
+func (m TreeMap[K, V]) find(key K) *treeNode[K, V] {
+	node := m.root
+	for node != nil {
+		c := m.comparator(key, node.key)
+		if c < 0 {
+			node = node.left
+		} else if c > 0 {
+			node = node.right
+		} else {
+			return node
+		}
+	}
+	return nil
+}
+
+// largest key below key, inclusive decides whether key itself counts
+func (m TreeMap[K, V]) below(key K, inclusive bool) (K, bool) {
+	var best *treeNode[K, V]
+	node := m.root
+	for node != nil {
+		c := m.comparator(key, node.key)
+		if c == 0 && inclusive {
+			return node.key, true
+		} else if c <= 0 {
+			node = node.left
+		} else {
+			best = node
+			node = node.right
+		}
+	}
+	if best == nil {
+		var nilVal K
+		return nilVal, false
+	}
+	return best.key, true
+}
+
+// smallest key above key, inclusive decides whether key itself counts
+func (m TreeMap[K, V]) above(key K, inclusive bool) (K, bool) {
+	var best *treeNode[K, V]
+	node := m.root
+	for node != nil {
+		c := m.comparator(key, node.key)
+		if c == 0 && inclusive {
+			return node.key, true
+		} else if c >= 0 {
+			node = node.right
+		} else {
+			best = node
+			node = node.left
+		}
+	}
+	if best == nil {
+		var nilVal K
+		return nilVal, false
+	}
+	return best.key, true
+}
+
+func (m *TreeMap[K, V]) put(h *treeNode[K, V], key K, value V) *treeNode[K, V] {
+	if h == nil {
+		m.size++
+		return &treeNode[K, V]{key: key, value: value, red: true}
+	}
+	c := m.comparator(key, h.key)
+	if c < 0 {
+		h.left = m.put(h.left, key, value)
+	} else if c > 0 {
+		h.right = m.put(h.right, key, value)
+	} else {
+		h.value = value
+	}
+	return balance(h)
+}
+
+// assumes the key is in the subtree rooted at h
+func (m *TreeMap[K, V]) remove(h *treeNode[K, V], key K) *treeNode[K, V] {
+	if m.comparator(key, h.key) < 0 {
+		if !isRed(h.left) && !isRed(h.left.left) {
+			h = moveRedLeft(h)
+		}
+		h.left = m.remove(h.left, key)
+	} else {
+		if isRed(h.left) {
+			h = rotateRight(h)
+		}
+		if m.comparator(key, h.key) == 0 && h.right == nil {
+			return nil
+		}
+		if !isRed(h.right) && !isRed(h.right.left) {
+			h = moveRedRight(h)
+		}
+		if m.comparator(key, h.key) == 0 {
+			successor := minNode(h.right)
+			h.key = successor.key
+			h.value = successor.value
+			h.right = removeMin(h.right)
+		} else {
+			h.right = m.remove(h.right, key)
+		}
+	}
+	return balance(h)
+}
+
+func (m TreeMap[K, V]) ascendRange(h *treeNode[K, V], from K, to K, visit func(K, V) bool) bool {
+	if h == nil {
+		return true
+	}
+	lowerOk := m.comparator(from, h.key) <= 0
+	upperOk := m.comparator(h.key, to) < 0
+	if lowerOk && !m.ascendRange(h.left, from, to, visit) {
+		return false
+	}
+	if lowerOk && upperOk && !visit(h.key, h.value) {
+		return false
+	}
+	if upperOk {
+		return m.ascendRange(h.right, from, to, visit)
+	}
+	return true
+}
+
+func ascend[K comparable, V interface{}](h *treeNode[K, V], visit func(K, V) bool) bool {
+	if h == nil {
+		return true
+	}
+	return ascend(h.left, visit) && visit(h.key, h.value) && ascend(h.right, visit)
+}
+
+func descend[K comparable, V interface{}](h *treeNode[K, V], visit func(K, V) bool) bool {
+	if h == nil {
+		return true
+	}
+	return descend(h.right, visit) && visit(h.key, h.value) && descend(h.left, visit)
+}
+
+func isRed[K comparable, V interface{}](h *treeNode[K, V]) bool {
+	return h != nil && h.red
+}
+
+func minNode[K comparable, V interface{}](h *treeNode[K, V]) *treeNode[K, V] {
+	for h.left != nil {
+		h = h.left
+	}
+	return h
+}
+
+func removeMin[K comparable, V interface{}](h *treeNode[K, V]) *treeNode[K, V] {
+	if h.left == nil {
+		return nil
+	}
+	if !isRed(h.left) && !isRed(h.left.left) {
+		h = moveRedLeft(h)
+	}
+	h.left = removeMin(h.left)
+	return balance(h)
+}
+
+func rotateLeft[K comparable, V interface{}](h *treeNode[K, V]) *treeNode[K, V] {
+	x := h.right
+	h.right = x.left
+	x.left = h
+	x.red = h.red
+	h.red = true
+	return x
+}
+
+func rotateRight[K comparable, V interface{}](h *treeNode[K, V]) *treeNode[K, V] {
+	x := h.left
+	h.left = x.right
+	x.right = h
+	x.red = h.red
+	h.red = true
+	return x
+}
+
+func flipColors[K comparable, V interface{}](h *treeNode[K, V]) {
+	h.red = !h.red
+	h.left.red = !h.left.red
+	h.right.red = !h.right.red
+}
+
+func moveRedLeft[K comparable, V interface{}](h *treeNode[K, V]) *treeNode[K, V] {
+	flipColors(h)
+	if isRed(h.right.left) {
+		h.right = rotateRight(h.right)
+		h = rotateLeft(h)
+		flipColors(h)
+	}
+	return h
+}
+
+func moveRedRight[K comparable, V interface{}](h *treeNode[K, V]) *treeNode[K, V] {
+	flipColors(h)
+	if isRed(h.left.left) {
+		h = rotateRight(h)
+		flipColors(h)
+	}
+	return h
+}
+
+// restores the left-leaning red-black invariants on the way up
+func balance[K comparable, V interface{}](h *treeNode[K, V]) *treeNode[K, V] {
+	if isRed(h.right) && !isRed(h.left) {
+		h = rotateLeft(h)
+	}
+	if isRed(h.left) && isRed(h.left.left) {
+		h = rotateRight(h)
+	}
+	if isRed(h.left) && isRed(h.right) {
+		flipColors(h)
+	}
+	return h
+}
@@ -0,0 +1,268 @@
+package utils
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestTreeMap_PutAndGet(t *testing.T) {
+	m := NewTreeMap[int, string](cmp)
+	m.Put(2, "Two")
+	m.Put(1, "One")
+	m.Put(3, "Three")
+	m.Put(2, "NewTwo")
+
+	if m.Size() != 3 {
+		t.Errorf("Expected size 3, but got %d", m.Size())
+	}
+	if result := m.Get(2); result != "NewTwo" {
+		t.Errorf("Expected 'NewTwo' for key 2, but got '%s'", result)
+	}
+	if result := m.Get(4); result != "" {
+		t.Errorf("Expected an empty string for key 4, but got '%s'", result)
+	}
+	if !m.ContainsKey(1) || m.ContainsKey(4) {
+		t.Errorf("ContainsKey returned the wrong result")
+	}
+}
+
+func TestTreeMap_KeysAndValuesAreSorted(t *testing.T) {
+	m := NewTreeMap[int, int](cmp)
+	for _, k := range []int{5, 3, 8, 1, 4, 7, 9, 2, 6} {
+		m.Put(k, k*10)
+	}
+
+	expectedKeys := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	expectedValues := []int{10, 20, 30, 40, 50, 60, 70, 80, 90}
+	if !reflect.DeepEqual(m.Keys(), expectedKeys) {
+		t.Errorf("Expected keys %v, but got %v", expectedKeys, m.Keys())
+	}
+	if !reflect.DeepEqual(m.Values(), expectedValues) {
+		t.Errorf("Expected values %v, but got %v", expectedValues, m.Values())
+	}
+}
+
+func TestTreeMap_Merge(t *testing.T) {
+	var m Map[string, int] = NewTreeMap[string, int](func(a, b string) int {
+		if a < b {
+			return -1
+		} else if a > b {
+			return 1
+		}
+		return 0
+	})
+	sum := func(a, b int) int { return a + b }
+	for _, word := range []string{"b", "a", "b", "c", "b"} {
+		m.Merge(word, 1, sum)
+	}
+	if m.Get("b") != 3 || m.Get("a") != 1 || m.Get("c") != 1 {
+		t.Errorf("Merge produced the wrong counts: %v %v", m.Keys(), m.Values())
+	}
+}
+
+func TestTreeMap_Navigation(t *testing.T) {
+	m := NewTreeMap[int, bool](cmp)
+	for _, k := range []int{10, 20, 30, 40} {
+		m.Put(k, true)
+	}
+
+	check := func(name string, key int, ok bool, expectedKey int, expectedOk bool) {
+		if ok != expectedOk || (ok && key != expectedKey) {
+			t.Errorf("%s: expected (%d, %v), but got (%d, %v)", name, expectedKey, expectedOk, key, ok)
+		}
+	}
+
+	k, ok := m.Floor(25)
+	check("Floor(25)", k, ok, 20, true)
+	k, ok = m.Floor(20)
+	check("Floor(20)", k, ok, 20, true)
+	k, ok = m.Floor(5)
+	check("Floor(5)", k, ok, 0, false)
+
+	k, ok = m.Lower(20)
+	check("Lower(20)", k, ok, 10, true)
+	k, ok = m.Lower(10)
+	check("Lower(10)", k, ok, 0, false)
+
+	k, ok = m.Ceiling(25)
+	check("Ceiling(25)", k, ok, 30, true)
+	k, ok = m.Ceiling(30)
+	check("Ceiling(30)", k, ok, 30, true)
+	k, ok = m.Ceiling(45)
+	check("Ceiling(45)", k, ok, 0, false)
+
+	k, ok = m.Higher(30)
+	check("Higher(30)", k, ok, 40, true)
+	k, ok = m.Higher(40)
+	check("Higher(40)", k, ok, 0, false)
+
+	first, err := m.First()
+	if err != nil || first != 10 {
+		t.Errorf("Expected First 10, but got %d (err: %v)", first, err)
+	}
+	last, err := m.Last()
+	if err != nil || last != 40 {
+		t.Errorf("Expected Last 40, but got %d (err: %v)", last, err)
+	}
+}
+
+func TestTreeMap_FirstLastEmpty(t *testing.T) {
+	m := NewTreeMap[int, int](cmp)
+	if _, err := m.First(); err == nil {
+		t.Errorf("First should return an error when the map is empty")
+	}
+	if _, err := m.Last(); err == nil {
+		t.Errorf("Last should return an error when the map is empty")
+	}
+}
+
+func TestTreeMap_RangeAndIteration(t *testing.T) {
+	m := NewTreeMap[int, int](cmp)
+	for i := 0; i < 20; i++ {
+		m.Put(i, i)
+	}
+
+	expected := []int{5, 6, 7, 8, 9}
+	if keys := m.KeysBetween(5, 10); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Expected %v, but got %v", expected, keys)
+	}
+
+	descending := make([]int, 0)
+	m.Descend(func(k int, _ int) bool {
+		descending = append(descending, k)
+		return k > 17
+	})
+	expected = []int{19, 18, 17}
+	if !reflect.DeepEqual(descending, expected) {
+		t.Errorf("Expected %v, but got %v", expected, descending)
+	}
+
+	ascending := make([]int, 0)
+	m.AscendRange(3, 100, func(k int, _ int) bool {
+		ascending = append(ascending, k)
+		return len(ascending) < 2
+	})
+	expected = []int{3, 4}
+	if !reflect.DeepEqual(ascending, expected) {
+		t.Errorf("Expected %v, but got %v", expected, ascending)
+	}
+}
+
+func TestTreeMap_RandomOperations(t *testing.T) {
+	m := NewTreeMap[int, int](cmp)
+	reference := NewMapWrapper[int, int]()
+	for i := 0; i < 5000; i++ {
+		key := rand.Intn(500)
+		if rand.Intn(3) == 0 {
+			m.Remove(key)
+			reference.Remove(key)
+		} else {
+			m.Put(key, i)
+			reference.Put(key, i)
+		}
+	}
+
+	if err := llrbInvariant(m.root); err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if m.Size() != reference.Size() {
+		t.Errorf("Expected size %d, but got %d", reference.Size(), m.Size())
+	}
+	keys := reference.Keys()
+	sort.Ints(keys)
+	if !reflect.DeepEqual(m.Keys(), keys) {
+		t.Errorf("Expected keys %v, but got %v", keys, m.Keys())
+	}
+	for _, k := range keys {
+		if m.Get(k) != reference.Get(k) {
+			t.Errorf("Expected %d for key %d, but got %d", reference.Get(k), k, m.Get(k))
+		}
+	}
+
+	for _, k := range keys {
+		m.Remove(k)
+	}
+	if m.Size() != 0 || m.root != nil {
+		t.Errorf("Expected an empty tree after removing every key")
+	}
+}
+
+func TestTreeMap_Iterators(t *testing.T) {
+	source := NewMapWrapper[int, string]()
+	for i, s := range []string{"Zero", "One", "Two", "Three", "Four"} {
+		source.Put(i, s)
+	}
+	m := CollectTreeMap(source.All(), cmp)
+
+	if keys := slices.Collect(m.KeysSeq()); !reflect.DeepEqual(keys, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("Expected KeysSeq in ascending order, but got %v", keys)
+	}
+	if vals := slices.Collect(m.ValuesSeq()); !reflect.DeepEqual(vals, m.Values()) {
+		t.Errorf("Expected ValuesSeq to match Values, but got %v", vals)
+	}
+
+	backward := make([]int, 0)
+	for k := range m.Backward() {
+		backward = append(backward, k)
+	}
+	if !reflect.DeepEqual(backward, []int{4, 3, 2, 1, 0}) {
+		t.Errorf("Expected Backward in descending order, but got %v", backward)
+	}
+
+	ranged := make([]string, 0)
+	for _, v := range m.Range(1, 3) {
+		ranged = append(ranged, v)
+	}
+	if !reflect.DeepEqual(ranged, []string{"One", "Two"}) {
+		t.Errorf("Expected Range(1, 3) to yield [One Two], but got %v", ranged)
+	}
+
+	for k := range m.All() {
+		if k == 2 {
+			break
+		}
+		if k > 2 {
+			t.Errorf("Expected All to stop at the break")
+		}
+	}
+}
+
+// checks that there are no red right links, no two reds in a row and a perfect black balance
+func llrbInvariant[K comparable, V interface{}](root *treeNode[K, V]) error {
+	if isRed(root) {
+		return errors.New("Root is red")
+	}
+	_, err := blackHeight(root)
+	return err
+}
+
+func blackHeight[K comparable, V interface{}](h *treeNode[K, V]) (int, error) {
+	if h == nil {
+		return 0, nil
+	}
+	if isRed(h.right) {
+		return 0, errors.New("Red right link")
+	}
+	if isRed(h) && isRed(h.left) {
+		return 0, errors.New("Two red links in a row")
+	}
+	left, err := blackHeight(h.left)
+	if err != nil {
+		return 0, err
+	}
+	right, err := blackHeight(h.right)
+	if err != nil {
+		return 0, err
+	}
+	if left != right {
+		return 0, errors.New("Black heights differ")
+	}
+	if !isRed(h) {
+		left++
+	}
+	return left, nil
+}
@@ -0,0 +1,414 @@
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+/*
+A source of the current time and of the janitor's ticks
+Inject your own implementation to control time in tests
+After works like time.After: the channel receives the time once d has passed on this clock
+*/
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+/*
+An implementation of the Map interface where entries expire after a time-to-live
+Expired entries are never returned and are removed lazily when they are looked up.
+A janitor goroutine can be started to remove them in the background as well.
+TTLMap is safe for concurrent use
+*/
+type TTLMap[K comparable, V interface{}] struct {
+	mu         sync.Mutex
+	items      map[K]ttlEntry[V]
+	defaultTTL time.Duration
+	clock      Clock
+	stop       chan struct{}
+	done       chan struct{}
+}
+
+type ttlEntry[V interface{}] struct {
+	value V
+	// the zero time means that the entry never expires
+	expiresAt time.Time
+}
+
+/*
+O(1)
+Instantiates a new empty TTLMap that uses the system clock
+Entries added by Put and Merge live for defaultTTL, a defaultTTL <= 0 means they never expire
+*/
+func NewTTLMap[K comparable, V interface{}](defaultTTL time.Duration) *TTLMap[K, V] {
+	return NewTTLMapWithClock[K, V](defaultTTL, systemClock{})
+}
+
+/*
+O(1)
+Instantiates a new empty TTLMap that reads the time from the supplied clock
+*/
+func NewTTLMapWithClock[K comparable, V interface{}](defaultTTL time.Duration, clock Clock) *TTLMap[K, V] {
+	return &TTLMap[K, V]{
+		items:      make(map[K]ttlEntry[V]),
+		defaultTTL: defaultTTL,
+		clock:      clock}
+}
+
+/*
+O(1)
+Assumes: TTLMap m has been instantiated
+Ensures: the key is mapped to the value for the default time-to-live
+*/
+func (m *TTLMap[K, V]) Put(key K, value V) {
+	m.PutWithTTL(key, value, m.defaultTTL)
+}
+
+/*
+O(1)
+Assumes: TTLMap m has been instantiated
+Ensures: the key is mapped to the value for the supplied time-to-live, a ttl <= 0 means forever
+*/
+func (m *TTLMap[K, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.items[key] = m.newEntry(value, ttl)
+}
+
+/*
+O(1)
+Assumes: TTLMap m has been instantiated
+Returns the value that is associated with the supplied key
+If there is no live mapping, the function will return the nil value of the value type
+*/
+func (m *TTLMap[K, V]) Get(key K) V {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, _ := m.lookup(key)
+	return entry.value
+}
+
+/*
+O(1)
+Assumes: TTLMap m has been instantiated
+Returns the value that is associated with the supplied key and true,
+or the nil value of the value type and false if there is no live mapping
+*/
+func (m *TTLMap[K, V]) GetOk(key K) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, exists := m.lookup(key)
+	return entry.value, exists
+}
+
+/*
+O(1)
+Assumes: TTLMap m has been instantiated
+Returns the value that is associated with the supplied key, or defaultValue if there is no live mapping
+*/
+func (m *TTLMap[K, V]) GetOrDefault(key K, defaultValue V) V {
+	if value, exists := m.GetOk(key); exists {
+		return value
+	}
+	return defaultValue
+}
+
+/*
+O(1)
+Assumes: TTLMap m has been instantiated
+Maps the key to the value for the default time-to-live only if there is no live mapping with the supplied key
+Returns the value the key is mapped to afterwards and true if it was already mapped
+An existing mapping keeps its expiry time
+*/
+func (m *TTLMap[K, V]) PutIfAbsent(key K, value V) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if entry, exists := m.lookup(key); exists {
+		return entry.value, true
+	}
+	m.items[key] = m.newEntry(value, m.defaultTTL)
+	return value, false
+}
+
+/*
+O(1)
+Assumes: TTLMap m has been instantiated
+Maps the key to the value for the default time-to-live only if there already is a live mapping with the supplied key
+Returns the replaced value and true, or the nil value of the value type and false if nothing was replaced
+*/
+func (m *TTLMap[K, V]) Replace(key K, value V) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, exists := m.lookup(key)
+	if exists {
+		m.items[key] = m.newEntry(value, m.defaultTTL)
+	}
+	return entry.value, exists
+}
+
+/*
+O(1)
+Assumes: TTLMap m has been instantiated
+Removes the mapping with the supplied key
+Returns the removed value and true, or the nil value of the value type and false if there was no live mapping
+*/
+func (m *TTLMap[K, V]) Remove(key K) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, exists := m.lookup(key)
+	delete(m.items, key)
+	return entry.value, exists
+}
+
+/*
+O(n)
+Assumes: TTLMap m has been instantiated
+Returns the keys of the live mappings as a slice
+*/
+func (m *TTLMap[K, V]) Keys() []K {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.clock.Now()
+	keys := make([]K, 0, len(m.items))
+	for k, entry := range m.items {
+		if !entry.expired(now) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+/*
+O(n)
+Assumes: TTLMap m has been instantiated
+Returns the values of the live mappings as a slice
+*/
+func (m *TTLMap[K, V]) Values() []V {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.clock.Now()
+	vals := make([]V, 0, len(m.items))
+	for _, entry := range m.items {
+		if !entry.expired(now) {
+			vals = append(vals, entry.value)
+		}
+	}
+	return vals
+}
+
+/*
+O(1)
+Assumes: TTLMap m has been instantiated
+Returns true if there exist a live mapping with the supplied key
+*/
+func (m *TTLMap[K, V]) ContainsKey(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, exists := m.lookup(key)
+	return exists
+}
+
+/*
+O(1)
+Assumes: TTLMap m has been instantiated
+If there exists a live mapping with the supplied key, the new value will be mergeOp(newVal, oldVal)
+If not, it works as a regular Put
+Either way the mapping lives for the default time-to-live from now
+*/
+func (m *TTLMap[K, V]) Merge(key K, newVal V, mergeOp func(V, V) V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if entry, exists := m.lookup(key); exists {
+		newVal = mergeOp(newVal, entry.value)
+	}
+	m.items[key] = m.newEntry(newVal, m.defaultTTL)
+}
+
+/*
+O(1)
+Assumes: TTLMap m has been instantiated
+Atomically updates the mapping with the supplied key
+remap receives the current live value and whether it exists, and returns the new value
+and whether the mapping should exist afterwards, so returning false removes it
+A kept mapping lives for the default time-to-live from now
+Returns the value after the update and whether the mapping exists
+remap runs while the map is locked and must not use the map
+*/
+func (m *TTLMap[K, V]) Compute(key K, remap func(V, bool) (V, bool)) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, exists := m.lookup(key)
+	value, keep := remap(entry.value, exists)
+	if !keep {
+		delete(m.items, key)
+		var nilVal V
+		return nilVal, false
+	}
+	m.items[key] = m.newEntry(value, m.defaultTTL)
+	return value, true
+}
+
+/*
+O(1)
+Assumes: TTLMap m has been instantiated
+Adds a mapping with the value returned by create if there is no mapping with the supplied key
+Nothing is added if create returns false
+Returns the value after the update and whether the mapping exists
+The function runs while the map is locked and must not use the map
+*/
+func (m *TTLMap[K, V]) ComputeIfAbsent(key K, create func() (V, bool)) (V, bool) {
+	return m.Compute(key, absentRemap(create))
+}
+
+/*
+O(1)
+Assumes: TTLMap m has been instantiated
+Replaces the value of an existing mapping with the value returned by remap
+The mapping is removed if remap returns false, and nothing happens if there is no mapping
+Returns the value after the update and whether the mapping exists
+The function runs while the map is locked and must not use the map
+*/
+func (m *TTLMap[K, V]) ComputeIfPresent(key K, remap func(V) (V, bool)) (V, bool) {
+	return m.Compute(key, presentRemap(remap))
+}
+
+/*
+O(n)
+Assumes: TTLMap m has been instantiated
+Returns the number of live mappings
+*/
+func (m *TTLMap[K, V]) Size() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.clock.Now()
+	size := 0
+	for _, entry := range m.items {
+		if !entry.expired(now) {
+			size++
+		}
+	}
+	return size
+}
+
+/*
+O(1)
+Assumes: TTLMap m has been instantiated
+Returns the time left before the mapping with the supplied key expires
+The boolean is false if there is no live mapping or if it never expires
+*/
+func (m *TTLMap[K, V]) TTL(key K) (time.Duration, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, exists := m.lookup(key)
+	if !exists || entry.expiresAt.IsZero() {
+		return 0, false
+	}
+	return entry.expiresAt.Sub(m.clock.Now()), true
+}
+
+/*
+O(n)
+Assumes: TTLMap m has been instantiated
+Removes every expired mapping and returns how many were removed
+*/
+func (m *TTLMap[K, V]) RemoveExpired() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.clock.Now()
+	removed := 0
+	for k, entry := range m.items {
+		if entry.expired(now) {
+			delete(m.items, k)
+			removed++
+		}
+	}
+	return removed
+}
+
+/*
+Assumes: TTLMap m has been instantiated
+Starts a goroutine that calls RemoveExpired every interval until StopJanitor is called
+The interval is measured on the map's clock
+No-op if the janitor is already running or the interval is not positive
+*/
+func (m *TTLMap[K, V]) StartJanitor(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stop != nil {
+		return
+	}
+	m.stop = make(chan struct{})
+	m.done = make(chan struct{})
+	go m.janitor(interval, m.stop, m.done)
+}
+
+/*
+Assumes: TTLMap m has been instantiated
+Stops the janitor goroutine and waits for it to exit
+No-op if the janitor is not running
+*/
+func (m *TTLMap[K, V]) StopJanitor() {
+	m.mu.Lock()
+	stop, done := m.stop, m.done
+	m.stop = nil
+	m.done = nil
+	m.mu.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+}
+
+// PRIVATE HELPER FUNCTIONS BELOW
+
+func (m *TTLMap[K, V]) newEntry(value V, ttl time.Duration) ttlEntry[V] {
+	entry := ttlEntry[V]{value: value}
+	if ttl > 0 {
+		entry.expiresAt = m.clock.Now().Add(ttl)
+	}
+	return entry
+}
+
+// assumes the lock is held, removes the mapping if it has expired
+func (m *TTLMap[K, V]) lookup(key K) (ttlEntry[V], bool) {
+	entry, exists := m.items[key]
+	if !exists {
+		return ttlEntry[V]{}, false
+	}
+	if entry.expired(m.clock.Now()) {
+		delete(m.items, key)
+		return ttlEntry[V]{}, false
+	}
+	return entry, true
+}
+
+func (m *TTLMap[K, V]) janitor(interval time.Duration, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	for {
+		select {
+		case <-m.clock.After(interval):
+			m.RemoveExpired()
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (e ttlEntry[V]) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
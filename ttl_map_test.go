@@ -0,0 +1,233 @@
+package utils
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// a clock that only moves when told to, its timers fire when Advance reaches them
+type fakeClock struct {
+	mu      sync.Mutex
+	changed *sync.Cond
+	now     time.Time
+	timers  []fakeTimer
+}
+
+type fakeTimer struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	c := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	c.changed = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	c.timers = append(c.timers, fakeTimer{at: c.now.Add(d), ch: ch})
+	c.changed.Broadcast()
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.timers[:0]
+	for _, timer := range c.timers {
+		if timer.at.After(c.now) {
+			pending = append(pending, timer)
+		} else {
+			timer.ch <- c.now
+		}
+	}
+	c.timers = pending
+}
+
+// blocks until n timers are waiting to fire
+func (c *fakeClock) WaitForTimers(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.timers) < n {
+		c.changed.Wait()
+	}
+}
+
+func TestTTLMap_LazyExpiry(t *testing.T) {
+	clock := newFakeClock()
+	m := NewTTLMapWithClock[string, int](time.Minute, clock)
+	m.Put("a", 1)
+	m.PutWithTTL("b", 2, 2*time.Minute)
+	m.PutWithTTL("c", 3, 0)
+
+	clock.Advance(59 * time.Second)
+	if m.Get("a") != 1 || !m.ContainsKey("a") {
+		t.Errorf("Expected key 'a' to still be live")
+	}
+
+	clock.Advance(time.Second)
+	if m.ContainsKey("a") || m.Get("a") != 0 {
+		t.Errorf("Expected key 'a' to have expired")
+	}
+	if _, stored := m.items["a"]; stored {
+		t.Errorf("Expected the lookup to remove the expired entry")
+	}
+	if m.Size() != 2 {
+		t.Errorf("Expected size 2, but got %d", m.Size())
+	}
+
+	clock.Advance(time.Hour)
+	keys := m.Keys()
+	if !reflect.DeepEqual(keys, []string{"c"}) {
+		t.Errorf("Expected only the key without a ttl to remain, but got %v", keys)
+	}
+	if !reflect.DeepEqual(m.Values(), []int{3}) {
+		t.Errorf("Expected values [3], but got %v", m.Values())
+	}
+}
+
+func TestTTLMap_PutRefreshesTTL(t *testing.T) {
+	clock := newFakeClock()
+	var m Map[string, int] = NewTTLMapWithClock[string, int](time.Minute, clock)
+	sum := func(a, b int) int { return a + b }
+	m.Merge("a", 1, sum)
+
+	clock.Advance(30 * time.Second)
+	m.Merge("a", 1, sum)
+
+	clock.Advance(45 * time.Second)
+	if m.Get("a") != 2 {
+		t.Errorf("Expected the merged value 2, but got %d", m.Get("a"))
+	}
+
+	// An expired entry is not merged with
+	clock.Advance(time.Minute)
+	m.Merge("a", 1, sum)
+	if m.Get("a") != 1 {
+		t.Errorf("Expected a fresh value 1, but got %d", m.Get("a"))
+	}
+}
+
+func TestTTLMap_TTL(t *testing.T) {
+	clock := newFakeClock()
+	m := NewTTLMapWithClock[int, int](time.Minute, clock)
+	m.Put(1, 1)
+	m.PutWithTTL(2, 2, -1)
+
+	clock.Advance(20 * time.Second)
+	if left, ok := m.TTL(1); !ok || left != 40*time.Second {
+		t.Errorf("Expected 40s left, but got %v (%v)", left, ok)
+	}
+	if _, ok := m.TTL(2); ok {
+		t.Errorf("Expected no ttl for an entry that never expires")
+	}
+	if _, ok := m.TTL(3); ok {
+		t.Errorf("Expected no ttl for a missing entry")
+	}
+}
+
+func TestTTLMap_RemoveExpired(t *testing.T) {
+	clock := newFakeClock()
+	m := NewTTLMapWithClock[int, int](time.Minute, clock)
+	for i := 0; i < 10; i++ {
+		m.PutWithTTL(i, i, time.Duration(i+1)*time.Second)
+	}
+
+	clock.Advance(5 * time.Second)
+	if removed := m.RemoveExpired(); removed != 5 {
+		t.Errorf("Expected 5 removed entries, but got %d", removed)
+	}
+	keys := m.Keys()
+	sort.Ints(keys)
+	expected := []int{5, 6, 7, 8, 9}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Expected keys %v, but got %v", expected, keys)
+	}
+}
+
+func TestTTLMap_Janitor(t *testing.T) {
+	clock := newFakeClock()
+	m := NewTTLMapWithClock[int, int](time.Minute, clock)
+	m.Put(1, 1)
+	m.PutWithTTL(2, 2, 3*time.Minute)
+	m.StartJanitor(2 * time.Minute)
+	m.StartJanitor(2 * time.Minute)
+	defer m.StopJanitor()
+
+	stored := func() int {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return len(m.items)
+	}
+
+	// the janitor has not ticked yet, so the expired entry is still stored
+	clock.WaitForTimers(1)
+	clock.Advance(time.Minute)
+	if stored() != 2 {
+		t.Errorf("Expected the janitor to wait for its interval, but %d entries are left", stored())
+	}
+
+	// once a sweep is done the janitor waits for its next tick
+	clock.Advance(time.Minute)
+	clock.WaitForTimers(1)
+	if stored() != 1 {
+		t.Errorf("Expected the janitor to remove the expired entry, but %d entries are left", stored())
+	}
+
+	clock.Advance(2 * time.Minute)
+	clock.WaitForTimers(1)
+	if stored() != 0 {
+		t.Errorf("Expected the janitor to remove every expired entry, but %d entries are left", stored())
+	}
+
+	m.StopJanitor()
+	m.StopJanitor()
+	if m.stop != nil {
+		t.Errorf("Expected the janitor to be stopped")
+	}
+}
+
+func TestTTLMap_JanitorIgnoresNonPositiveIntervals(t *testing.T) {
+	m := NewTTLMapWithClock[int, int](time.Minute, newFakeClock())
+	m.StartJanitor(0)
+	m.StartJanitor(-time.Second)
+	if m.stop != nil {
+		t.Errorf("Expected a non-positive interval to leave the janitor stopped")
+	}
+	m.StopJanitor()
+}
+
+func TestTTLMap_ExpiredEntriesAreAbsent(t *testing.T) {
+	clock := newFakeClock()
+	m := NewTTLMapWithClock[string, int](time.Minute, clock)
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 3)
+	clock.Advance(time.Minute)
+
+	if _, ok := m.GetOk("a"); ok {
+		t.Errorf("Expected GetOk to treat an expired entry as missing")
+	}
+	if value, existed := m.PutIfAbsent("b", 20); existed || value != 20 {
+		t.Errorf("Expected PutIfAbsent to replace an expired entry, but got (%d, %v)", value, existed)
+	}
+	if _, removed := m.Remove("c"); removed {
+		t.Errorf("Expected Remove to report an expired entry as missing")
+	}
+	if _, replaced := m.Replace("c", 30); replaced {
+		t.Errorf("Expected Replace to ignore an expired entry")
+	}
+}